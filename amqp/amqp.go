@@ -13,15 +13,34 @@ package amqp
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 
+	"github.com/goxkit/tracing/internal/amqppropagator"
+	tracingpropagation "github.com/goxkit/tracing/propagation"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	// instrumentationName is the instrumentation scope name of the default tracer
+	instrumentationName = "github.com/goxkit/tracing/amqp"
+
+	// DLQEvent is the name of the span event recorded when a message is dead-lettered
+	DLQEvent = "messaging.dlq"
+
+	// DLQReasonKey is the attribute key of the DLQ event holding the dead-letter reason
+	DLQReasonKey = attribute.Key("messaging.dlq.reason")
+)
+
 // Traceparent represents the components of a trace context that are propagated between services.
 // It contains the identifiers and flags needed to correlate spans across service boundaries
 // when using message queuing.
@@ -37,15 +56,151 @@ type Traceparent struct {
 }
 
 var (
-	// AMQPPropagator is a composite propagator that combines TraceContext and Baggage propagation
-	// for AMQP messaging contexts. This enables both trace correlation and contextual properties
-	// to be passed between services.
-	AMQPPropagator = propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	)
+	// AMQPPropagator is a composite propagator for AMQP messaging contexts, made of the
+	// propagators listed by OTEL_PROPAGATORS. By default it injects TraceContext and Baggage,
+	// and also accepts B3 and Jaeger on extraction. This enables both trace correlation and
+	// contextual properties to be passed between services, whichever trace format the other
+	// services send.
+	AMQPPropagator = tracingpropagation.FromEnv()
+
+	// ConsumerSpanNameFormatter builds the name of the spans created by NewConsumerSpan from
+	// the consumer type (e.g. queue name). It defaults to "consume.<typ>" and can be replaced
+	// to follow other naming conventions, such as OpenTelemetry's "<queue> receive".
+	ConsumerSpanNameFormatter = func(destination string) string {
+		return fmt.Sprintf("consume.%s", destination)
+	}
+
+	// ErrInvalidTraceparent is returned when a traceparent string is malformed
+	ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+	// defaultTracer holds the tracer used by the helpers that do not take a tracer parameter
+	defaultTracer atomic.Value
+
+	// customPropagator holds the propagator configured with SetPropagator
+	customPropagator atomic.Value
 )
 
+// SetTracer configures the tracer used package-wide by the helpers that do not take a
+// tracer parameter, such as NewConsumerSpanDefault. Until it is called, a tracer from
+// the global tracer provider is used.
+//
+// Parameters:
+//   - tracer: The tracer to use by default
+func SetTracer(tracer trace.Tracer) {
+	defaultTracer.Store(&tracer)
+}
+
+// Tracer returns the tracer used package-wide by the helpers that do not take a tracer parameter.
+//
+// Returns:
+//   - trace.Tracer: The configured default tracer, or a tracer from the global provider
+func Tracer() trace.Tracer {
+	if tracer, ok := defaultTracer.Load().(*trace.Tracer); ok {
+		return *tracer
+	}
+
+	return otel.Tracer(instrumentationName)
+}
+
+// SetPropagator configures the propagator used package-wide to inject and extract the trace
+// context of AMQP messages, in place of AMQPPropagator and of the propagator configured by
+// the installation. It lets services whose brokers or consumers cannot handle large headers,
+// such as baggage, restrict what goes on the wire, e.g. with propagation.TraceContext{} alone.
+// Passing nil restores the propagator of the installation, or AMQPPropagator.
+//
+// Example usage:
+//
+//	amqp.SetPropagator(propagation.TraceContext{})
+//
+// Parameters:
+//   - p: The propagator to use for AMQP headers
+func SetPropagator(p propagation.TextMapPropagator) {
+	customPropagator.Store(&p)
+}
+
+// propagator returns the propagator configured with SetPropagator, then the one configured
+// by the installation (e.g. with options.WithPropagator), or AMQPPropagator.
+//
+// Returns:
+//   - propagation.TextMapPropagator: The propagator used for AMQP headers
+func propagator() propagation.TextMapPropagator {
+	if p, ok := customPropagator.Load().(*propagation.TextMapPropagator); ok && *p != nil {
+		return *p
+	}
+
+	if p := amqppropagator.Get(); p != nil {
+		return p
+	}
+
+	return AMQPPropagator
+}
+
+// ParseTraceparent parses a W3C traceparent string in the "00-<trace-id>-<span-id>-<flags>"
+// format, such as the value of the traceparent header, with lowercase hexadecimal fields.
+// All-zero trace and span IDs are rejected, as are versions other than 00.
+//
+// Parameters:
+//   - s: The traceparent string
+//
+// Returns:
+//   - Traceparent: The parsed trace context
+//   - error: ErrInvalidTraceparent wrapped with the reason when s is malformed
+func ParseTraceparent(s string) (Traceparent, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 {
+		return Traceparent{}, fmt.Errorf("%w: expected 4 fields, got %d", ErrInvalidTraceparent, len(parts))
+	}
+
+	if parts[0] != "00" {
+		return Traceparent{}, fmt.Errorf("%w: unsupported version %q", ErrInvalidTraceparent, parts[0])
+	}
+
+	var tp Traceparent
+
+	if err := decodeHex(parts[1], tp.TraceID[:]); err != nil || !tp.TraceID.IsValid() {
+		return Traceparent{}, fmt.Errorf("%w: invalid trace id %q", ErrInvalidTraceparent, parts[1])
+	}
+
+	if err := decodeHex(parts[2], tp.SpanID[:]); err != nil || !tp.SpanID.IsValid() {
+		return Traceparent{}, fmt.Errorf("%w: invalid span id %q", ErrInvalidTraceparent, parts[2])
+	}
+
+	var flags [1]byte
+	if err := decodeHex(parts[3], flags[:]); err != nil {
+		return Traceparent{}, fmt.Errorf("%w: invalid flags %q", ErrInvalidTraceparent, parts[3])
+	}
+	tp.TraceFlags = trace.TraceFlags(flags[0])
+
+	return tp, nil
+}
+
+// decodeHex decodes a lowercase hexadecimal field filling dst exactly.
+//
+// Parameters:
+//   - field: The hexadecimal field
+//   - dst: The destination, whose length is the expected number of bytes
+//
+// Returns:
+//   - error: Any error encountered while decoding
+func decodeHex(field string, dst []byte) error {
+	if len(field) != hex.EncodedLen(len(dst)) || strings.ToLower(field) != field {
+		return ErrInvalidTraceparent
+	}
+
+	_, err := hex.Decode(dst, []byte(field))
+
+	return err
+}
+
+// String formats the trace context as a W3C traceparent string
+// ("00-<trace-id>-<span-id>-<flags>").
+//
+// Returns:
+//   - string: The traceparent string
+func (tp Traceparent) String() string {
+	return fmt.Sprintf("00-%s-%s-%s", tp.TraceID, tp.SpanID, tp.TraceFlags)
+}
+
 // AMQPHeader wraps amqp.Table to implement the TextMapCarrier interface for OpenTelemetry propagation.
 // This allows trace context to be injected into and extracted from AMQP message headers.
 type AMQPHeader amqp.Table
@@ -54,12 +209,22 @@ type AMQPHeader amqp.Table
 // This implements part of the TextMapCarrier interface required by OpenTelemetry
 // for context propagation.
 //
+// The key is stored lowercased. Entries whose key only differs by case, such as a stale
+// "Traceparent" set by another library, are the same header and are removed, so the
+// message never carries two conflicting values; headers with other keys are untouched.
+//
 // Parameters:
 //   - key: The header key (will be converted to lowercase)
 //   - val: The header value to set
 func (h AMQPHeader) Set(key, val string) {
 	key = strings.ToLower(key)
 
+	for k := range h {
+		if k != key && strings.EqualFold(k, key) {
+			delete(h, k)
+		}
+	}
+
 	h[key] = val
 }
 
@@ -67,15 +232,16 @@ func (h AMQPHeader) Set(key, val string) {
 // This implements part of the TextMapCarrier interface required by OpenTelemetry
 // for context propagation.
 //
+// The lowercased key is looked up first, then any key differing only by case, so headers
+// set with their original case by the application (e.g. "X-App-Id") remain retrievable.
+//
 // Parameters:
-//   - key: The header key to retrieve (will be converted to lowercase)
+//   - key: The header key to retrieve
 //
 // Returns:
 //   - string: The header value, or empty string if not found or not a string
 func (h AMQPHeader) Get(key string) string {
-	key = strings.ToLower(key)
-
-	value, ok := h[key]
+	value, ok := h.lookup(key)
 
 	if !ok {
 		return ""
@@ -90,7 +256,22 @@ func (h AMQPHeader) Get(key string) string {
 	return toString
 }
 
-// Keys returns a sorted list of all keys in the AMQP header.
+// lookup finds the value of a key, preferring its lowercased form over other case variants.
+func (h AMQPHeader) lookup(key string) (any, bool) {
+	if value, ok := h[strings.ToLower(key)]; ok {
+		return value, true
+	}
+
+	for k, value := range h {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Keys returns a sorted list of all keys in the AMQP header, with their stored case.
 // This implements part of the TextMapCarrier interface required by OpenTelemetry
 // for context propagation.
 //
@@ -108,19 +289,250 @@ func (h AMQPHeader) Keys() []string {
 	return keys
 }
 
-// NewConsumerSpan creates a new span for AMQP message consumption with the trace context
+// NewConsumerSpan creates a new consumer span for AMQP message consumption with the trace context
 // extracted from the message headers. This allows continuation of a trace that was
 // started in a producer service, maintaining the end-to-end transaction context.
 //
 // Parameters:
 //   - tracer: The OpenTelemetry tracer to create the span
 //   - header: The AMQP message headers containing the trace context
-//   - typ: The type of consumer, used to name the span (e.g., queue name)
+//   - typ: The type of consumer, used to name the span through ConsumerSpanNameFormatter (e.g., queue name)
 //
 // Returns:
 //   - context.Context: Context with the extracted trace information
 //   - trace.Span: The new span created for this consumer operation
 func NewConsumerSpan(tracer trace.Tracer, header amqp.Table, typ string) (context.Context, trace.Span) {
-	ctx := AMQPPropagator.Extract(context.Background(), AMQPHeader(header))
-	return tracer.Start(ctx, fmt.Sprintf("consume.%s", typ))
+	ctx := propagator().Extract(context.Background(), AMQPHeader(header))
+	return tracer.Start(ctx, ConsumerSpanNameFormatter(typ), trace.WithSpanKind(trace.SpanKindConsumer))
+}
+
+// NewConsumerSpanFromDelivery behaves like NewConsumerSpan for a whole delivery, and records
+// the message and correlation IDs of the delivery as the messaging.message.id and
+// messaging.message.conversation_id span attributes, so the span can be matched with a
+// specific message in the broker. IDs that are not set on the delivery are not recorded.
+//
+// Parameters:
+//   - tracer: The OpenTelemetry tracer to create the span
+//   - delivery: The delivery being consumed
+//   - typ: The type of consumer, used to name the span through ConsumerSpanNameFormatter (e.g., queue name)
+//
+// Returns:
+//   - context.Context: Context with the extracted trace information
+//   - trace.Span: The new span created for this consumer operation
+func NewConsumerSpanFromDelivery(tracer trace.Tracer, delivery amqp.Delivery, typ string) (context.Context, trace.Span) {
+	ctx, span := NewConsumerSpan(tracer, delivery.Headers, typ)
+
+	if delivery.MessageId != "" {
+		span.SetAttributes(attribute.String("messaging.message.id", delivery.MessageId))
+	}
+
+	if delivery.CorrelationId != "" {
+		span.SetAttributes(attribute.String("messaging.message.conversation_id", delivery.CorrelationId))
+	}
+
+	return ctx, span
+}
+
+// publisherConfig holds the settings of NewPublisherSpan.
+type publisherConfig struct {
+	// traceProperties sets the blank message properties from the trace context
+	traceProperties bool
+}
+
+// PublisherOption configures NewPublisherSpan.
+type PublisherOption func(*publisherConfig)
+
+// WithTraceProperties makes NewPublisherSpan also set the native MessageId property of the
+// message to the publisher span ID and its CorrelationId property to the trace ID, when they
+// are empty, so consumers correlating on properties rather than headers can still match the
+// message with its trace. Properties already set are left untouched, and the headers remain
+// the primary propagation mechanism.
+//
+// Returns:
+//   - PublisherOption: The trace properties option
+func WithTraceProperties() PublisherOption {
+	return func(c *publisherConfig) {
+		c.traceProperties = true
+	}
+}
+
+// NewPublisherSpan creates a new producer span for publishing an AMQP message and injects
+// the resulting trace context into the message headers, so the consumer can continue
+// the same trace. The message headers are initialized when nil.
+//
+// Example usage:
+//
+//	msg := amqp.Publishing{Body: body}
+//	ctx, span := tracingamqp.NewPublisherSpan(ctx, tracer, &msg, "orders", tracingamqp.WithTraceProperties())
+//	defer span.End()
+//
+//	err := ch.PublishWithContext(ctx, "orders", "created", false, false, msg)
+//
+// Parameters:
+//   - ctx: The parent context of the publish operation
+//   - tracer: The OpenTelemetry tracer to create the span
+//   - msg: The AMQP message about to be published
+//   - destination: The exchange or queue the message is published to, used to name the span
+//   - opts: Options such as WithTraceProperties
+//
+// Returns:
+//   - context.Context: Context containing the publisher span
+//   - trace.Span: The new span created for this publish operation
+func NewPublisherSpan(ctx context.Context, tracer trace.Tracer, msg *amqp.Publishing, destination string, opts ...PublisherOption) (context.Context, trace.Span) {
+	cfg := &publisherConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	ctx, span := tracer.Start(ctx, fmt.Sprintf("publish.%s", destination), trace.WithSpanKind(trace.SpanKindProducer))
+
+	InjectAMQP(ctx, &msg.Headers)
+
+	if sc := span.SpanContext(); cfg.traceProperties && sc.IsValid() {
+		if msg.MessageId == "" {
+			msg.MessageId = sc.SpanID().String()
+		}
+
+		if msg.CorrelationId == "" {
+			msg.CorrelationId = sc.TraceID().String()
+		}
+	}
+
+	return ctx, span
+}
+
+// NewConsumerSpanDefault behaves like NewConsumerSpan using the package-wide tracer
+// configured with SetTracer, so the tracer does not need to be threaded through consumers.
+//
+// Parameters:
+//   - header: The AMQP message headers containing the trace context
+//   - typ: The type of consumer, used to name the span through ConsumerSpanNameFormatter (e.g., queue name)
+//
+// Returns:
+//   - context.Context: Context with the extracted trace information
+//   - trace.Span: The new span created for this consumer operation
+func NewConsumerSpanDefault(header amqp.Table, typ string) (context.Context, trace.Span) {
+	return NewConsumerSpan(Tracer(), header, typ)
+}
+
+// ConsumeWithSpan processes a delivery within a consumer span. It extracts the trace context
+// from the delivery headers, starts the span as NewConsumerSpanFromDelivery does, runs the
+// handler with the span context, records the returned error on the span with an error status,
+// and ends the span once the handler returns.
+//
+// Example usage:
+//
+//	for delivery := range deliveries {
+//		err := tracingamqp.ConsumeWithSpan(tracer, delivery, "orders", func(ctx context.Context) error {
+//			return handle(ctx, delivery.Body)
+//		})
+//	}
+//
+// Parameters:
+//   - tracer: The OpenTelemetry tracer to create the span
+//   - delivery: The delivery to process
+//   - typ: The type of consumer, used to name the span through ConsumerSpanNameFormatter (e.g., queue name)
+//   - fn: The handler processing the delivery
+//
+// Returns:
+//   - error: The error returned by the handler
+func ConsumeWithSpan(tracer trace.Tracer, delivery amqp.Delivery, typ string, fn func(ctx context.Context) error) error {
+	ctx, span := NewConsumerSpanFromDelivery(tracer, delivery, typ)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RecordDLQ records on a consume span that its message is sent to a dead-letter queue, as a
+// messaging.dlq event carrying the reason in the messaging.dlq.reason attribute, and sets the
+// error status of the span with the reason as description, so dead-lettered messages are
+// reported the same way by every consumer.
+//
+// Example usage:
+//
+//	if err := handle(ctx, delivery.Body); err != nil {
+//		tracingamqp.RecordDLQ(span, "handler failed: "+err.Error())
+//		_ = delivery.Nack(false, false)
+//	}
+//
+// Parameters:
+//   - span: The consume span of the message
+//   - reason: Why the message is dead-lettered
+func RecordDLQ(span trace.Span, reason string) {
+	span.AddEvent(DLQEvent, trace.WithAttributes(DLQReasonKey.String(reason)))
+	span.SetStatus(codes.Error, reason)
+}
+
+// InjectAMQP injects the trace context of ctx into the headers of an outgoing AMQP message,
+// keeping the application headers already present. A nil table is initialized before the
+// injection, so it is safe to pass the Headers field of a fresh amqp.Publishing.
+//
+// The propagation keys are written lowercased (e.g. "traceparent"), as done by AMQPHeader.Set;
+// the keys of the existing application headers are left untouched.
+//
+// Example usage:
+//
+//	msg := amqp.Publishing{Body: body}
+//	tracingamqp.InjectAMQP(ctx, &msg.Headers)
+//
+// Parameters:
+//   - ctx: The context holding the trace context to propagate
+//   - table: The message headers to inject into; may point to a nil table
+//
+// Returns:
+//   - amqp.Table: The headers holding the trace context
+func InjectAMQP(ctx context.Context, table *amqp.Table) amqp.Table {
+	if table == nil {
+		table = &amqp.Table{}
+	}
+
+	if *table == nil {
+		*table = amqp.Table{}
+	}
+
+	propagator().Inject(ctx, AMQPHeader(*table))
+
+	return *table
+}
+
+// ContinueAndInject builds the headers of a message republished while consuming another one,
+// such as a retry or dead-letter publish, so the republished message continues the trace of
+// the consumer. The given headers, typically those of the consumed delivery, are copied into
+// a new table whose trace context is replaced with the one of ctx, the consumer span context;
+// the original headers are left untouched.
+//
+// Example usage:
+//
+//	ctx, span := tracingamqp.NewConsumerSpan(tracer, delivery.Headers, "orders")
+//	defer span.End()
+//
+//	if err := handle(ctx, delivery); err != nil {
+//		ch.PublishWithContext(ctx, "", "orders.retry", false, false, amqp.Publishing{
+//			Headers: tracingamqp.ContinueAndInject(ctx, delivery.Headers),
+//			Body:    delivery.Body,
+//		})
+//	}
+//
+// Parameters:
+//   - ctx: The context of the consumer span
+//   - headers: The headers to republish, may be nil
+//
+// Returns:
+//   - amqp.Table: A new table with the headers and the trace context of ctx
+func ContinueAndInject(ctx context.Context, headers amqp.Table) amqp.Table {
+	table := make(amqp.Table, len(headers)+2)
+
+	for key, value := range headers {
+		table[key] = value
+	}
+
+	propagator().Inject(ctx, AMQPHeader(table))
+
+	return table
 }
@@ -0,0 +1,436 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package amqp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goxkit/tracing/internal/amqppropagator"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestTracer returns a tracer whose ended spans are recorded by the returned recorder.
+func newTestTracer() (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return tp.Tracer("test"), recorder
+}
+
+// useTraceContext makes the package propagate the W3C trace context only until the test ends.
+func useTraceContext(t *testing.T) {
+	t.Helper()
+
+	SetPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { SetPropagator(nil) })
+}
+
+func TestConsumerSpanNameFormatter(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	_, span := NewConsumerSpan(tracer, nil, "orders")
+	span.End()
+
+	previous := ConsumerSpanNameFormatter
+	ConsumerSpanNameFormatter = func(destination string) string { return destination + " receive" }
+	t.Cleanup(func() { ConsumerSpanNameFormatter = previous })
+
+	_, span = NewConsumerSpan(tracer, nil, "orders")
+	span.End()
+
+	spans := recorder.Ended()
+	if got := spans[0].Name(); got != "consume.orders" {
+		t.Errorf("default name = %q, want consume.orders", got)
+	}
+
+	if got := spans[1].Name(); got != "orders receive" {
+		t.Errorf("formatted name = %q, want orders receive", got)
+	}
+}
+
+func TestSetTracerConfiguresDefaultTracer(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	// The default tracer cannot be unset, so the fallback to the global tracer provider is
+	// restored by configuring its tracer.
+	previous := Tracer()
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(previous) })
+
+	_, span := NewConsumerSpanDefault(nil, "orders")
+	span.End()
+
+	if got := len(recorder.Ended()); got != 1 {
+		t.Errorf("got %d spans recorded by the configured tracer, want 1", got)
+	}
+}
+
+func TestInjectAMQPInitializesNilTables(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	ctx, span := tracer.Start(context.Background(), "orders.publish")
+	defer span.End()
+
+	var headers amqp.Table
+	got := InjectAMQP(ctx, &headers)
+
+	if headers == nil || got["traceparent"] == nil {
+		t.Fatalf("headers = %v, want the table initialized with the trace context", headers)
+	}
+
+	if got := InjectAMQP(ctx, nil); got["traceparent"] == nil {
+		t.Errorf("InjectAMQP(nil) = %v, want a new table with the trace context", got)
+	}
+}
+
+func TestInjectAMQPKeepsExistingHeaders(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	ctx, span := tracer.Start(context.Background(), "orders.publish")
+	defer span.End()
+
+	headers := amqp.Table{"X-App-Id": "orders"}
+	InjectAMQP(ctx, &headers)
+
+	if headers["X-App-Id"] != "orders" {
+		t.Errorf("X-App-Id = %v, want the application header with its original case", headers["X-App-Id"])
+	}
+
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), AMQPHeader(headers)))
+	if !sc.Equal(trace.SpanContextFromContext(ctx).WithRemote(true)) {
+		t.Errorf("injected span context = %v, want the one of the publisher span", sc)
+	}
+}
+
+func TestConsumeWithSpanContinuesThePublisherTrace(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	publishCtx, publishSpan := tracer.Start(context.Background(), "orders.publish")
+	delivery := amqp.Delivery{Headers: InjectAMQP(publishCtx, nil)}
+	publishSpan.End()
+
+	var handlerSpan trace.SpanContext
+	err := ConsumeWithSpan(tracer, delivery, "orders", func(ctx context.Context) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ConsumeWithSpan: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d ended spans, want the publish span and the consume span ended once", len(spans))
+	}
+
+	span := spans[1]
+	if span.Parent().SpanID() != publishSpan.SpanContext().SpanID() {
+		t.Error("the consume span does not continue the trace of the delivery")
+	}
+
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("the handler did not run with the consume span context")
+	}
+
+	if span.Status().Code != codes.Unset || len(span.Events()) != 0 {
+		t.Errorf("span status = %v with %d events, want no error", span.Status(), len(span.Events()))
+	}
+}
+
+func TestConsumeWithSpanRecordsHandlerErrors(t *testing.T) {
+	tracer, recorder := newTestTracer()
+	failure := errors.New("invalid order")
+
+	if err := ConsumeWithSpan(tracer, amqp.Delivery{}, "orders", func(context.Context) error { return failure }); err != failure {
+		t.Fatalf("ConsumeWithSpan error = %v, want the error of the handler", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d ended spans, want the consume span ended once", len(spans))
+	}
+
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "invalid order" || len(spans[0].Events()) != 1 {
+		t.Errorf("span status = %v with %d events, want the recorded error", status, len(spans[0].Events()))
+	}
+}
+
+func TestParseTraceparentRoundTrips(t *testing.T) {
+	for _, s := range []string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+	} {
+		tp, err := ParseTraceparent(s)
+		if err != nil {
+			t.Fatalf("ParseTraceparent(%s): %v", s, err)
+		}
+
+		if got := tp.String(); got != s {
+			t.Errorf("String() = %s, want %s", got, s)
+		}
+	}
+
+	tp, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if tp.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || tp.SpanID.String() != "00f067aa0ba902b7" || !tp.TraceFlags.IsSampled() {
+		t.Errorf("parsed traceparent = %+v, want the fields of the string", tp)
+	}
+}
+
+func TestParseTraceparentRejectsMalformedStrings(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902zz-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+	} {
+		if _, err := ParseTraceparent(s); !errors.Is(err, ErrInvalidTraceparent) {
+			t.Errorf("ParseTraceparent(%q) error = %v, want ErrInvalidTraceparent", s, err)
+		}
+	}
+}
+
+func TestContinueAndInjectCarriesTheConsumerTrace(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	publishCtx, publishSpan := tracer.Start(context.Background(), "orders.publish")
+	publishSpan.End()
+
+	delivered := InjectAMQP(publishCtx, &amqp.Table{"x-retry-count": int32(1)})
+	original := delivered["traceparent"]
+
+	ctx, span := NewConsumerSpan(tracer, delivered, "orders")
+	defer span.End()
+
+	headers := ContinueAndInject(ctx, delivered)
+
+	if headers["x-retry-count"] != int32(1) {
+		t.Errorf("x-retry-count = %v, want the header of the consumed message", headers["x-retry-count"])
+	}
+
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), AMQPHeader(headers)))
+	if sc.TraceID() != publishSpan.SpanContext().TraceID() || sc.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("republished span context = %v, want the consumer span of the original trace", sc)
+	}
+
+	if delivered["traceparent"] != original {
+		t.Error("the headers of the consumed message were modified")
+	}
+}
+
+func TestSpanHelpersSetMessagingSpanKinds(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	msg := amqp.Publishing{}
+	_, span := NewPublisherSpan(context.Background(), tracer, &msg, "orders")
+	span.End()
+
+	_, span = NewConsumerSpan(tracer, msg.Headers, "orders")
+	span.End()
+
+	_, span = NewConsumerSpanFromDelivery(tracer, amqp.Delivery{Headers: msg.Headers}, "orders")
+	span.End()
+
+	want := []trace.SpanKind{trace.SpanKindProducer, trace.SpanKindConsumer, trace.SpanKindConsumer}
+	for i, span := range recorder.Ended() {
+		if span.SpanKind() != want[i] {
+			t.Errorf("%s kind = %s, want %s", span.Name(), span.SpanKind(), want[i])
+		}
+	}
+}
+
+func TestNewConsumerSpanFromDeliverySetsMessageIDs(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	_, span := NewConsumerSpanFromDelivery(tracer, amqp.Delivery{MessageId: "m-1", CorrelationId: "c-1"}, "orders")
+	span.End()
+
+	_, span = NewConsumerSpanFromDelivery(tracer, amqp.Delivery{}, "orders")
+	span.End()
+
+	spans := recorder.Ended()
+
+	want := map[attribute.Key]string{
+		"messaging.message.id":              "m-1",
+		"messaging.message.conversation_id": "c-1",
+	}
+	for _, kv := range spans[0].Attributes() {
+		if value, ok := want[kv.Key]; ok && kv.Value.AsString() == value {
+			delete(want, kv.Key)
+		}
+	}
+
+	if len(want) != 0 {
+		t.Errorf("attributes %v missing from %v", want, spans[0].Attributes())
+	}
+
+	if attrs := spans[1].Attributes(); len(attrs) != 0 {
+		t.Errorf("attributes = %v, want none for a delivery without IDs", attrs)
+	}
+}
+
+func TestAMQPHeaderKeepsMixedCaseApplicationHeaders(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	ctx, span := tracer.Start(context.Background(), "orders.publish")
+	defer span.End()
+
+	headers := amqp.Table{"X-App-Id": "orders", "x-tenant": "acme"}
+	InjectAMQP(ctx, &headers)
+
+	carrier := AMQPHeader(headers)
+	for key, want := range map[string]string{"X-App-Id": "orders", "x-app-id": "orders", "X-Tenant": "acme"} {
+		if got := carrier.Get(key); got != want {
+			t.Errorf("Get(%s) = %q, want %q", key, got, want)
+		}
+	}
+
+	keys := carrier.Keys()
+	want := []string{"X-App-Id", "traceparent", "x-tenant"}
+	if len(keys) != len(want) {
+		t.Fatalf("Keys() = %v, want %v", keys, want)
+	}
+
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("Keys() = %v, want %v", keys, want)
+			break
+		}
+	}
+}
+
+func TestAMQPHeaderGetIgnoresNonStringValues(t *testing.T) {
+	carrier := AMQPHeader{"x-retry-count": int32(2)}
+
+	if got := carrier.Get("x-retry-count"); got != "" {
+		t.Errorf("Get = %q, want empty for a non-string value", got)
+	}
+}
+
+func TestSetPropagatorRestrictsInjectedHeaders(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+
+	ctx, span := tracer.Start(baggage.ContextWithBaggage(context.Background(), bag), "orders.publish")
+	defer span.End()
+
+	if headers := InjectAMQP(ctx, nil); headers["baggage"] != "tenant=acme" {
+		t.Errorf("default headers = %v, want the baggage injected", headers)
+	}
+
+	useTraceContext(t)
+
+	headers := InjectAMQP(ctx, nil)
+	if _, ok := headers["baggage"]; ok || headers["traceparent"] == nil {
+		t.Errorf("headers = %v, want the trace context without baggage", headers)
+	}
+
+	SetPropagator(nil)
+
+	if headers := InjectAMQP(ctx, nil); headers["baggage"] != "tenant=acme" {
+		t.Errorf("headers after reset = %v, want the default propagator restored", headers)
+	}
+}
+
+func TestSetPropagatorTakesPrecedenceOverTheInstallation(t *testing.T) {
+	t.Cleanup(func() { amqppropagator.Set(nil) })
+
+	tracer, _ := newTestTracer()
+
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+
+	ctx, span := tracer.Start(baggage.ContextWithBaggage(context.Background(), bag), "orders.publish")
+	defer span.End()
+
+	amqppropagator.Set(propagation.Baggage{})
+
+	if headers := InjectAMQP(ctx, nil); len(headers) != 1 || headers["baggage"] != "tenant=acme" {
+		t.Errorf("headers = %v, want the baggage of the installed propagator only", headers)
+	}
+
+	useTraceContext(t)
+
+	if headers := InjectAMQP(ctx, nil); len(headers) != 1 || headers["traceparent"] == nil {
+		t.Errorf("headers = %v, want the trace context of the propagator set with SetPropagator", headers)
+	}
+}
+
+func TestNewPublisherSpanSetsBlankPropertiesFromTraceIDs(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	var msg amqp.Publishing
+	_, span := NewPublisherSpan(context.Background(), tracer, &msg, "orders", WithTraceProperties())
+	span.End()
+
+	if msg.MessageId != span.SpanContext().SpanID().String() {
+		t.Errorf("MessageId = %q, want the span ID", msg.MessageId)
+	}
+
+	if msg.CorrelationId != span.SpanContext().TraceID().String() {
+		t.Errorf("CorrelationId = %q, want the trace ID", msg.CorrelationId)
+	}
+
+	if msg.Headers["traceparent"] == nil {
+		t.Error("the trace context was not injected into the headers")
+	}
+}
+
+func TestNewPublisherSpanKeepsSetProperties(t *testing.T) {
+	tracer, _ := newTestTracer()
+
+	msg := amqp.Publishing{MessageId: "m-1", CorrelationId: "c-1"}
+	_, span := NewPublisherSpan(context.Background(), tracer, &msg, "orders", WithTraceProperties())
+	span.End()
+
+	if msg.MessageId != "m-1" || msg.CorrelationId != "c-1" {
+		t.Errorf("properties = (%q, %q), want them untouched", msg.MessageId, msg.CorrelationId)
+	}
+
+	var plain amqp.Publishing
+	_, span = NewPublisherSpan(context.Background(), tracer, &plain, "orders")
+	span.End()
+
+	if plain.MessageId != "" || plain.CorrelationId != "" {
+		t.Error("the properties were set without WithTraceProperties")
+	}
+}
+
+func TestRecordDLQ(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	_, span := NewConsumerSpan(tracer, amqp.Table{}, "orders")
+	RecordDLQ(span, "max redeliveries exceeded")
+	span.End()
+
+	ended := recorder.Ended()[0]
+	if ended.Status().Code != codes.Error || ended.Status().Description != "max redeliveries exceeded" {
+		t.Errorf("status = %v, want an error with the reason", ended.Status())
+	}
+
+	events := ended.Events()
+	if len(events) != 1 || events[0].Name != DLQEvent {
+		t.Fatalf("events = %v, want the %s event", events, DLQEvent)
+	}
+
+	if attrs := events[0].Attributes; len(attrs) != 1 || attrs[0] != DLQReasonKey.String("max redeliveries exceeded") {
+		t.Errorf("event attributes = %v, want the reason", attrs)
+	}
+}
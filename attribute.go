@@ -0,0 +1,339 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute converts a Go value into an attribute.KeyValue, choosing the attribute type
+// that matches the value. Unsigned integers beyond the int64 range are recorded as their
+// decimal string, and values of unsupported types using their fmt.Sprint representation.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The typed attribute
+func Attribute(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int8:
+		return attribute.Int64(key, int64(v))
+	case int16:
+		return attribute.Int64(key, int64(v))
+	case int32:
+		return attribute.Int64(key, int64(v))
+	case int64:
+		return attribute.Int64(key, v)
+	case uint8:
+		return attribute.Int64(key, int64(v))
+	case uint16:
+		return attribute.Int64(key, int64(v))
+	case uint32:
+		return attribute.Int64(key, int64(v))
+	case uint:
+		return uintAttribute(key, uint64(v))
+	case uint64:
+		return uintAttribute(key, v)
+	case uintptr:
+		return uintAttribute(key, uint64(v))
+	case float32:
+		return attribute.Float64(key, float64(v))
+	case float64:
+		return attribute.Float64(key, v)
+	case []string:
+		return attribute.StringSlice(key, v)
+	case []bool:
+		return attribute.BoolSlice(key, v)
+	case []int:
+		return attribute.IntSlice(key, v)
+	case []int64:
+		return attribute.Int64Slice(key, v)
+	case []float64:
+		return attribute.Float64Slice(key, v)
+	case time.Duration:
+		return attribute.String(key, v.String())
+	case time.Time:
+		return attribute.String(key, v.Format(time.RFC3339Nano))
+	case error:
+		return attribute.String(key, v.Error())
+	case fmt.Stringer:
+		return attribute.String(key, v.String())
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
+
+// uintAttribute records an unsigned integer as an int64 attribute, or as its decimal string
+// when it overflows int64.
+func uintAttribute(key string, v uint64) attribute.KeyValue {
+	if v > math.MaxInt64 {
+		return attribute.String(key, strconv.FormatUint(v, 10))
+	}
+
+	return attribute.Int64(key, int64(v))
+}
+
+// Attributes converts a map of Go values into attributes, sorted by key so the
+// resulting order is deterministic.
+//
+// Parameters:
+//   - values: The attribute values indexed by key
+//
+// Returns:
+//   - []attribute.KeyValue: The typed attributes
+func Attributes(values map[string]any) []attribute.KeyValue {
+	keys := make([]string, 0, len(values))
+
+	for k := range values {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, Attribute(k, values[k]))
+	}
+
+	return attrs
+}
+
+// AddEvent adds a structured event to the span, converting the given Go values into
+// typed event attributes.
+//
+// Example usage:
+//
+//	tracing.AddEvent(span, "order.validated", map[string]any{
+//		"order.items": 3,
+//		"order.id":    orderID,
+//		"order.rush":  true,
+//	})
+//
+// Parameters:
+//   - span: The span receiving the event
+//   - name: The event name
+//   - attrs: The event attributes indexed by key
+func AddEvent(span trace.Span, name string, attrs map[string]any) {
+	span.AddEvent(name, trace.WithAttributes(Attributes(attrs)...))
+}
+
+// StringAttr creates a string attribute.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The string attribute
+func StringAttr(key, value string) attribute.KeyValue {
+	return attribute.String(key, value)
+}
+
+// IntAttr creates an integer attribute.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The integer attribute
+func IntAttr(key string, value int) attribute.KeyValue {
+	return attribute.Int(key, value)
+}
+
+// Int64Attr creates a 64-bit integer attribute.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The integer attribute
+func Int64Attr(key string, value int64) attribute.KeyValue {
+	return attribute.Int64(key, value)
+}
+
+// BoolAttr creates a boolean attribute.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The boolean attribute
+func BoolAttr(key string, value bool) attribute.KeyValue {
+	return attribute.Bool(key, value)
+}
+
+// Float64Attr creates a floating point attribute.
+//
+// Parameters:
+//   - key: The attribute key
+//   - value: The attribute value
+//
+// Returns:
+//   - attribute.KeyValue: The floating point attribute
+func Float64Attr(key string, value float64) attribute.KeyValue {
+	return attribute.Float64(key, value)
+}
+
+// ErrAttr creates the error.type attribute holding the class of the error, its Go type
+// (e.g. *fs.PathError), so errors can be grouped in the backend regardless of their message.
+// A nil error produces an empty, invalid attribute, which the SDK drops and counts as a
+// dropped attribute of the span; use ErrAttrs when the error may be nil.
+//
+// Parameters:
+//   - err: The error to classify
+//
+// Returns:
+//   - attribute.KeyValue: The error class attribute
+func ErrAttr(err error) attribute.KeyValue {
+	if err == nil {
+		return attribute.KeyValue{}
+	}
+
+	return attribute.String("error.type", fmt.Sprintf("%T", err))
+}
+
+// ErrAttrs returns the error.type attribute created by ErrAttr, or no attribute for a nil
+// error, so it can be set on a span whether or not the operation failed.
+//
+// Example usage:
+//
+//	tracing.SetAttrs(span, tracing.ErrAttrs(err)...)
+//
+// Parameters:
+//   - err: The error to classify, nil for none
+//
+// Returns:
+//   - []attribute.KeyValue: The error class attribute, nil for a nil error
+func ErrAttrs(err error) []attribute.KeyValue {
+	if err == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{ErrAttr(err)}
+}
+
+// SetAttrs sets the attributes on the span.
+//
+// Example usage:
+//
+//	tracing.SetAttrs(span,
+//		tracing.StringAttr("order.id", orderID),
+//		tracing.IntAttr("order.items", len(items)),
+//	)
+//
+// Parameters:
+//   - span: The span receiving the attributes
+//   - attrs: The attributes to set
+func SetAttrs(span trace.Span, attrs ...attribute.KeyValue) {
+	span.SetAttributes(attrs...)
+}
+
+// SetAttr sets the attributes on the active span of the context, without fetching the span.
+// It does nothing when the context holds no recording span.
+//
+// Example usage:
+//
+//	tracing.SetAttr(ctx, tracing.StringAttr("order.id", orderID))
+//
+// Parameters:
+//   - ctx: The context containing the active span
+//   - attrs: The attributes to set
+func SetAttr(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
+
+// RecordError records the error on the active span of the context and sets the span status
+// to error with the error message. It does nothing when the error is nil or the context
+// holds no recording span.
+//
+// Example usage:
+//
+//	if err != nil {
+//		tracing.RecordError(ctx, err)
+//		return err
+//	}
+//
+// Parameters:
+//   - ctx: The context containing the active span
+//   - err: The error to record
+func RecordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Retryable is implemented by errors telling whether the failed operation can be retried,
+// recorded as the error.retryable attribute by RecordClassifiedError.
+type Retryable interface {
+	// Retryable reports whether the failed operation can be retried
+	Retryable() bool
+}
+
+// RecordClassifiedError records the error on the active span of the context like RecordError,
+// and classifies it so errors can be grouped and filtered in the backend: error.type holds
+// the Go type of the root cause of the error, found by unwrapping it (e.g. *net.OpError
+// rather than *fmt.wrapError). It is set on the span, and on the exception event along with
+// error.retryable when the error, or an error it wraps, implements Retryable. The event also
+// carries the exception.type and exception.message attributes written by RecordError. It
+// does nothing when the error is nil or the context holds no recording span.
+//
+// Example usage:
+//
+//	if err != nil {
+//		tracing.RecordClassifiedError(ctx, err)
+//		return err
+//	}
+//
+// Parameters:
+//   - ctx: The context containing the active span
+//   - err: The error to record
+func RecordClassifiedError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	cause := err
+	for next := errors.Unwrap(cause); next != nil; next = errors.Unwrap(cause) {
+		cause = next
+	}
+
+	errorType := attribute.String("error.type", fmt.Sprintf("%T", cause))
+	classified := []attribute.KeyValue{errorType}
+
+	var retryable Retryable
+	if errors.As(err, &retryable) {
+		classified = append(classified, attribute.Bool("error.retryable", retryable.Retryable()))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(errorType)
+	span.RecordError(err, trace.WithAttributes(classified...))
+	span.SetStatus(codes.Error, err.Error())
+}
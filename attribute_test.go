@@ -0,0 +1,235 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestAttributeTypes(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		value any
+		want  attribute.Value
+	}{
+		{"a", attribute.StringValue("a")},
+		{true, attribute.BoolValue(true)},
+		{3, attribute.IntValue(3)},
+		{int32(3), attribute.Int64Value(3)},
+		{uint16(3), attribute.Int64Value(3)},
+		{uint(3), attribute.Int64Value(3)},
+		{uint64(math.MaxInt64), attribute.Int64Value(math.MaxInt64)},
+		{uint64(math.MaxUint64), attribute.StringValue("18446744073709551615")},
+		{uintptr(3), attribute.Int64Value(3)},
+		{float32(1.5), attribute.Float64Value(1.5)},
+		{[]string{"a", "b"}, attribute.StringSliceValue([]string{"a", "b"})},
+		{[]int{1, 2}, attribute.IntSliceValue([]int{1, 2})},
+		{time.Second, attribute.StringValue("1s")},
+		{at, attribute.StringValue("2025-01-02T03:04:05Z")},
+		{errors.New("boom"), attribute.StringValue("boom")},
+		{struct{ ID int }{7}, attribute.StringValue("{7}")},
+	}
+
+	for _, tt := range tests {
+		if got := Attribute("k", tt.value).Value; got != tt.want {
+			t.Errorf("Attribute(%#v) = %s %v, want %s %v", tt.value, got.Type(), got.Emit(), tt.want.Type(), tt.want.Emit())
+		}
+	}
+}
+
+func TestAddEventRecordsSortedAttributes(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	_, span := Tracer("").Start(context.Background(), "orders.validate")
+	AddEvent(span, "order.validated", map[string]any{
+		"order.rush":  true,
+		"order.id":    "o-1",
+		"order.items": 3,
+	})
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	if len(events) != 1 || events[0].Name != "order.validated" {
+		t.Fatalf("events = %v, want a single order.validated event", events)
+	}
+
+	want := []attribute.KeyValue{
+		attribute.String("order.id", "o-1"),
+		attribute.Int("order.items", 3),
+		attribute.Bool("order.rush", true),
+	}
+	if got := events[0].Attributes; len(got) != len(want) {
+		t.Fatalf("event attributes = %v, want %v", got, want)
+	}
+
+	for i, kv := range events[0].Attributes {
+		if kv != want[i] {
+			t.Errorf("event attribute %d = %v, want %v", i, kv, want[i])
+		}
+	}
+}
+
+func TestTypedAttributeHelpers(t *testing.T) {
+	tests := []struct {
+		got  attribute.KeyValue
+		want attribute.KeyValue
+	}{
+		{StringAttr("order.id", "o-1"), attribute.String("order.id", "o-1")},
+		{IntAttr("order.items", 3), attribute.Int("order.items", 3)},
+		{Int64Attr("order.total", 4200), attribute.Int64("order.total", 4200)},
+		{BoolAttr("order.rush", true), attribute.Bool("order.rush", true)},
+		{Float64Attr("order.discount", 0.15), attribute.Float64("order.discount", 0.15)},
+		{ErrAttr(context.DeadlineExceeded), attribute.String("error.type", "context.deadlineExceededError")},
+		{ErrAttr(&time.ParseError{}), attribute.String("error.type", "*time.ParseError")},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s=%s, want %s=%s", tt.got.Key, tt.got.Value.Emit(), tt.want.Key, tt.want.Value.Emit())
+		}
+	}
+
+	if ErrAttr(nil).Valid() {
+		t.Error("ErrAttr(nil) is a valid attribute")
+	}
+}
+
+func TestErrAttrs(t *testing.T) {
+	if attrs := ErrAttrs(nil); attrs != nil {
+		t.Errorf("ErrAttrs(nil) = %v, want no attribute", attrs)
+	}
+
+	if attrs := ErrAttrs(context.Canceled); len(attrs) != 1 || attrs[0] != ErrAttr(context.Canceled) {
+		t.Errorf("ErrAttrs = %v, want the ErrAttr attribute", attrs)
+	}
+}
+
+func TestSetAttrsWithoutError(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	_, span := Tracer("").Start(context.Background(), "orders.create")
+	SetAttrs(span, StringAttr("order.id", "o-1"))
+	SetAttrs(span, ErrAttrs(nil)...)
+	span.End()
+
+	got := recorder.Ended()[0]
+	if attrs := got.Attributes(); len(attrs) != 1 || attrs[0] != attribute.String("order.id", "o-1") {
+		t.Errorf("attributes = %v, want order.id only", attrs)
+	}
+
+	if got.DroppedAttributes() != 0 {
+		t.Errorf("got %d dropped attributes, want 0", got.DroppedAttributes())
+	}
+}
+
+func TestSetAttrAndRecordErrorAnnotateTheActiveSpan(t *testing.T) {
+	recorder := installTestProvider(t)
+	errPayment := errors.New("payment declined")
+
+	ctx, span := Tracer("").Start(context.Background(), "orders.create")
+	SetAttr(ctx, StringAttr("order.id", "o-1"))
+	RecordError(ctx, errPayment)
+	RecordError(ctx, nil)
+	span.End()
+
+	ended := recorder.Ended()[0]
+	if !hasAttribute(ended.Attributes(), attribute.String("order.id", "o-1")) {
+		t.Errorf("attributes = %v, want order.id", ended.Attributes())
+	}
+
+	if ended.Status().Code != codes.Error || ended.Status().Description != errPayment.Error() {
+		t.Errorf("status = %v, want an error with the error message", ended.Status())
+	}
+
+	if events := ended.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("events = %v, want a single exception event", events)
+	}
+}
+
+func TestSetAttrAndRecordErrorWithoutSpan(t *testing.T) {
+	SetAttr(context.Background(), StringAttr("order.id", "o-1"))
+	RecordError(context.Background(), errors.New("payment declined"))
+}
+
+// timeoutError is a retryable error of the tests.
+type timeoutError struct{}
+
+// Error returns the error message.
+func (timeoutError) Error() string {
+	return "upstream timeout"
+}
+
+// Retryable reports that the failed operation can be retried.
+func (timeoutError) Retryable() bool {
+	return true
+}
+
+func TestRecordClassifiedErrorClassifiesTheRootCause(t *testing.T) {
+	recorder := installTestProvider(t)
+	err := fmt.Errorf("charging order o-1: %w", timeoutError{})
+
+	ctx, span := Tracer("").Start(context.Background(), "orders.pay")
+	RecordClassifiedError(ctx, err)
+	span.End()
+
+	ended := recorder.Ended()[0]
+	errorType := attribute.String("error.type", "tracing.timeoutError")
+
+	if attrs := ended.Attributes(); len(attrs) != 1 || attrs[0] != errorType {
+		t.Errorf("span attributes = %v, want error.type only", attrs)
+	}
+
+	if len(ended.Events()) != 1 {
+		t.Fatalf("got %d events, want a single exception event", len(ended.Events()))
+	}
+
+	event := ended.Events()[0]
+	for _, want := range []attribute.KeyValue{
+		errorType,
+		attribute.Bool("error.retryable", true),
+		attribute.String("exception.message", err.Error()),
+	} {
+		if !hasAttribute(event.Attributes, want) {
+			t.Errorf("event attribute %s=%s missing", want.Key, want.Value.Emit())
+		}
+	}
+
+	if ended.Status().Code != codes.Error {
+		t.Errorf("status = %v, want an error", ended.Status().Code)
+	}
+}
+
+func TestRecordClassifiedErrorWithoutRetryable(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, span := Tracer("").Start(context.Background(), "orders.pay")
+	RecordClassifiedError(ctx, errors.New("card declined"))
+	RecordClassifiedError(ctx, nil)
+	span.End()
+
+	ended := recorder.Ended()[0]
+	if !hasAttribute(ended.Attributes(), attribute.String("error.type", "*errors.errorString")) {
+		t.Errorf("attributes = %v, want the type of the error", ended.Attributes())
+	}
+
+	if len(ended.Events()) != 1 {
+		t.Fatalf("got %d events, want a single exception event", len(ended.Events()))
+	}
+
+	for _, kv := range ended.Events()[0].Attributes {
+		if kv.Key == "error.retryable" {
+			t.Error("error.retryable set for an error that does not tell")
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package echotracing provides tracing middleware for the Echo web framework.
+// The middleware continues inbound traces using the globally configured propagator
+// and starts a server span per request named after the matched route template,
+// keeping span names low-cardinality regardless of the path parameters.
+package echotracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/goxkit/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used for the tracer
+	instrumentationName = "github.com/goxkit/tracing/echotracing"
+)
+
+// Middleware returns an Echo middleware that traces each request with a server span.
+// The inbound trace context is extracted with the propagator installed by otlp.Install,
+// the span is started with the tracer provider installed by the tracing package and named
+// after the route template returned by c.Path() (e.g. /users/:id), and the span context is injected into c.Request().Context() for the handlers.
+// Errors returned by the handler are recorded on the span and passed to the Echo
+// error handler so the response status can be recorded.
+//
+// Example usage:
+//
+//	e := echo.New()
+//	e.Use(echotracing.Middleware())
+//
+// Returns:
+//   - echo.MiddlewareFunc: The tracing middleware
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := c.Path()
+			spanName := route
+			if spanName == "" {
+				spanName = fmt.Sprintf("HTTP %s", req.Method)
+			}
+
+			ctx, span := tracing.Tracer(instrumentationName).Start(
+				ctx,
+				spanName,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.method", req.Method),
+					attribute.String("http.route", route),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				span.RecordError(err)
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(attribute.Int("http.status_code", status))
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package echotracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceparent is the trace context sent by the caller in the tests.
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newTestServer returns a traced Echo instance, with a recorder of the ended spans and the
+// W3C trace context propagator installed globally until the test ends.
+func newTestServer(t *testing.T) (*echo.Echo, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	e := echo.New()
+	e.Use(Middleware())
+
+	return e, recorder
+}
+
+// hasAttribute reports whether the span holds the given attribute.
+func hasAttribute(span sdktrace.ReadOnlySpan, want attribute.KeyValue) bool {
+	for _, kv := range span.Attributes() {
+		if kv == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestMiddlewareNamesSpanAfterRoute(t *testing.T) {
+	e, recorder := newTestServer(t)
+
+	var handlerSpan trace.SpanContext
+	e.GET("/users/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "/users/:id" || span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span = %s (%s), want /users/:id (server)", span.Name(), span.SpanKind())
+	}
+
+	if got := span.Parent().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" || !span.Parent().IsRemote() {
+		t.Errorf("parent trace = %s, want the remote trace of the request", got)
+	}
+
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("the handler context does not hold the server span")
+	}
+
+	for _, kv := range []attribute.KeyValue{
+		attribute.String("http.method", http.MethodGet),
+		attribute.String("http.route", "/users/:id"),
+		attribute.Int("http.status_code", http.StatusNoContent),
+	} {
+		if !hasAttribute(span, kv) {
+			t.Errorf("attribute %s=%s missing", kv.Key, kv.Value.Emit())
+		}
+	}
+}
+
+func TestMiddlewareRecordsHandlerErrors(t *testing.T) {
+	e, recorder := newTestServer(t)
+
+	e.GET("/orders", func(echo.Context) error {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "maintenance")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("response status = %d, want 503", rec.Code)
+	}
+
+	span := recorder.Ended()[0]
+	if !hasAttribute(span, attribute.Int("http.status_code", http.StatusServiceUnavailable)) {
+		t.Error("http.status_code=503 missing")
+	}
+
+	if span.Status().Code != codes.Error || len(span.Events()) != 1 {
+		t.Errorf("span status = %v with %d events, want Error with the recorded error", span.Status(), len(span.Events()))
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package exporter provides span exporter wrappers complementing the exporters installed by
+// the tracing package, such as a circuit breaker protecting the application during collector
+// outages or a failover to a secondary collector.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// meterName is the instrumentation scope name used for the meters of this package
+	meterName = "github.com/goxkit/tracing/exporter"
+)
+
+var (
+	// ErrCircuitOpen is returned by the exports of a circuit breaker dropping the spans
+	// because it is open
+	ErrCircuitOpen = errors.New("export circuit breaker open: spans dropped")
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int64
+
+const (
+	// BreakerClosed lets exports through
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen drops spans without exporting them until the cooldown elapses
+	BreakerOpen
+
+	// BreakerHalfOpen lets a single probing export through after the cooldown
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker is a span exporter wrapper that stops exporting after consecutive failures.
+type CircuitBreaker struct {
+	sdktrace.SpanExporter
+
+	// failureThreshold is the number of consecutive failures opening the breaker
+	failureThreshold int
+
+	// cooldown is the time the breaker stays open before probing the exporter
+	cooldown time.Duration
+
+	// dropped counts the spans dropped while the breaker is open, if metrics are enabled
+	dropped metric.Int64Counter
+
+	// mu guards the fields below
+	mu sync.Mutex
+
+	// state is the current state
+	state BreakerState
+
+	// failures is the number of consecutive failed exports
+	failures int
+
+	// openedAt is the time the breaker last opened
+	openedAt time.Time
+}
+
+// NewCircuitBreaker wraps an exporter with a circuit breaker: after failureThreshold
+// consecutive failed exports, the breaker opens and spans are dropped right away, without
+// calling the exporter, for the cooldown; those exports return ErrCircuitOpen, so the drops
+// are not mistaken for successful exports. The next export then probes the exporter: the
+// breaker closes when it succeeds and opens again for another cooldown when it fails. It
+// saves the CPU, and the error logs, spent on exports bound to fail during a sustained
+// collector outage. When a meter provider is given, the exporter.circuit_breaker.state gauge
+// reports the state (0 closed, 1 open, 2 half-open) and the exporter.circuit_breaker.dropped
+// counter the spans dropped while open.
+//
+// Example usage:
+//
+//	breaker, err := exporter.NewCircuitBreaker(otlpExporter, 5, 30*time.Second, otel.GetMeterProvider())
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(breaker))
+//
+// Parameters:
+//   - exp: The exporter to protect
+//   - failureThreshold: The number of consecutive failures opening the breaker
+//   - cooldown: The time the breaker stays open before probing the exporter
+//   - provider: The meter provider used to create the instruments, nil to disable them
+//
+// Returns:
+//   - *CircuitBreaker: The exporter wrapped with the circuit breaker
+//   - error: Any error encountered while creating the instruments
+func NewCircuitBreaker(exp sdktrace.SpanExporter, failureThreshold int, cooldown time.Duration, provider metric.MeterProvider) (*CircuitBreaker, error) {
+	b := &CircuitBreaker{SpanExporter: exp, failureThreshold: failureThreshold, cooldown: cooldown}
+
+	if provider == nil {
+		return b, nil
+	}
+
+	meter := provider.Meter(meterName)
+
+	dropped, err := meter.Int64Counter(
+		"exporter.circuit_breaker.dropped",
+		metric.WithDescription("Number of spans dropped while the export circuit breaker is open"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	b.dropped = dropped
+
+	_, err = meter.Int64ObservableGauge(
+		"exporter.circuit_breaker.state",
+		metric.WithDescription("State of the export circuit breaker: 0 closed, 1 open, 2 half-open"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(b.State()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// State returns the current state of the breaker.
+//
+// Returns:
+//   - BreakerState: The current state
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// ExportSpans exports the spans unless the breaker is open, in which case they are dropped
+// and ErrCircuitOpen is returned.
+func (b *CircuitBreaker) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if !b.allow() {
+		if b.dropped != nil {
+			b.dropped.Add(ctx, int64(len(spans)))
+		}
+
+		return ErrCircuitOpen
+	}
+
+	err := b.SpanExporter.ExportSpans(ctx, spans)
+	b.record(err)
+
+	return err
+}
+
+// allow reports whether an export may go through, moving an open breaker whose cooldown
+// elapsed to half-open so the export probes the exporter.
+//
+// Returns:
+//   - bool: Whether the export may go through
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+
+		b.state = BreakerHalfOpen
+
+		return true
+	case BreakerHalfOpen:
+		// A probe is in flight.
+		return false
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of an export.
+//
+// Parameters:
+//   - err: The error returned by the export
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
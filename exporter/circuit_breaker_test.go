@@ -0,0 +1,184 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// errUnavailable is the error of the exports of a failing test exporter.
+var errUnavailable = errors.New("collector unavailable")
+
+// switchExporter is an exporter failing until it is told to recover.
+type switchExporter struct {
+	// mu guards the fields below
+	mu sync.Mutex
+
+	// err is the error returned by the exports, nil when they succeed
+	err error
+
+	// exports is the number of export calls
+	exports int
+}
+
+// ExportSpans counts the call and returns the configured error.
+func (e *switchExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.exports++
+
+	return e.err
+}
+
+// Shutdown does nothing.
+func (e *switchExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// set changes the error returned by the exports.
+func (e *switchExporter) set(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.err = err
+}
+
+// calls returns the number of export calls.
+func (e *switchExporter) calls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.exports
+}
+
+// expireCooldown makes the breaker behave as if its cooldown had elapsed.
+func expireCooldown(b *CircuitBreaker) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.openedAt = time.Now().Add(-b.cooldown)
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailuresAndRecovers(t *testing.T) {
+	exp := &switchExporter{err: errUnavailable}
+
+	breaker, err := NewCircuitBreaker(exp, 3, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := breaker.ExportSpans(context.Background(), nil); !errors.Is(err, errUnavailable) {
+			t.Fatalf("export %d = %v, want the exporter error", i+1, err)
+		}
+	}
+
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("state after 3 failures = %s, want open", breaker.State())
+	}
+
+	if err := breaker.ExportSpans(context.Background(), nil); !errors.Is(err, ErrCircuitOpen) || exp.calls() != 3 {
+		t.Fatalf("export while open = %v after %d calls, want ErrCircuitOpen without calling the exporter", err, exp.calls())
+	}
+
+	expireCooldown(breaker)
+	exp.set(nil)
+
+	if err := breaker.ExportSpans(context.Background(), nil); err != nil || breaker.State() != BreakerClosed {
+		t.Errorf("probe = %v with state %s, want a successful export closing the breaker", err, breaker.State())
+	}
+}
+
+func TestCircuitBreakerReopensWhenTheProbeFails(t *testing.T) {
+	exp := &switchExporter{err: errUnavailable}
+
+	breaker, err := NewCircuitBreaker(exp, 1, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker: %v", err)
+	}
+
+	_ = breaker.ExportSpans(context.Background(), nil)
+	expireCooldown(breaker)
+
+	if err := breaker.ExportSpans(context.Background(), nil); !errors.Is(err, errUnavailable) || exp.calls() != 2 {
+		t.Fatalf("probe = %v after %d calls, want the exporter error", err, exp.calls())
+	}
+
+	if breaker.State() != BreakerOpen {
+		t.Errorf("state after a failed probe = %s, want open", breaker.State())
+	}
+}
+
+func TestCircuitBreakerResetsFailuresOnSuccess(t *testing.T) {
+	exp := &switchExporter{err: errUnavailable}
+
+	breaker, err := NewCircuitBreaker(exp, 2, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker: %v", err)
+	}
+
+	_ = breaker.ExportSpans(context.Background(), nil)
+	exp.set(nil)
+	_ = breaker.ExportSpans(context.Background(), nil)
+	exp.set(errUnavailable)
+	_ = breaker.ExportSpans(context.Background(), nil)
+
+	if breaker.State() != BreakerClosed {
+		t.Errorf("state = %s, want closed as the failures were not consecutive", breaker.State())
+	}
+}
+
+func TestCircuitBreakerMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	exp := &switchExporter{err: errUnavailable}
+
+	breaker, err := NewCircuitBreaker(exp, 1, time.Hour, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker: %v", err)
+	}
+
+	_ = breaker.ExportSpans(context.Background(), nil)
+	_ = breaker.ExportSpans(context.Background(), make([]sdktrace.ReadOnlySpan, 4))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	values := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			values[m.Name] = data.DataPoints[0].Value
+		case metricdata.Gauge[int64]:
+			values[m.Name] = data.DataPoints[0].Value
+		}
+	}
+
+	if values["exporter.circuit_breaker.state"] != int64(BreakerOpen) {
+		t.Errorf("state gauge = %d, want %d", values["exporter.circuit_breaker.state"], BreakerOpen)
+	}
+
+	if values["exporter.circuit_breaker.dropped"] != 4 {
+		t.Errorf("dropped counter = %d, want 4", values["exporter.circuit_breaker.dropped"])
+	}
+}
+
+func TestBreakerStateString(t *testing.T) {
+	for state, want := range map[BreakerState]string{BreakerClosed: "closed", BreakerOpen: "open", BreakerHalfOpen: "half-open"} {
+		if got := state.String(); got != want {
+			t.Errorf("BreakerState(%d) = %s, want %s", state, got, want)
+		}
+	}
+}
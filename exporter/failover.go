@@ -0,0 +1,134 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// FailoverTargetKey is the attribute key of the failover export counter naming the exporter
+	// that delivered the spans: primary, secondary, or none when both failed
+	FailoverTargetKey = attribute.Key("exporter.failover.target")
+
+	// DefaultFailoverTimeout bounds each exporter attempt of a failover export when no timeout
+	// is given
+	DefaultFailoverTimeout = 10 * time.Second
+)
+
+// Failover is a span exporter sending spans to a primary exporter and falling back to a
+// secondary exporter when the primary fails.
+type Failover struct {
+	// primary receives the spans first
+	primary sdktrace.SpanExporter
+
+	// secondary receives the spans the primary failed to export
+	secondary sdktrace.SpanExporter
+
+	// timeout bounds the export of each exporter
+	timeout time.Duration
+
+	// exports counts the exports by delivering exporter, if metrics are enabled
+	exports metric.Int64Counter
+}
+
+// NewFailover creates an exporter sending spans to the primary exporter and, when the primary
+// fails, sending the same spans to the secondary exporter, e.g. two collectors deployed for
+// high availability. The primary is tried first on every export, so exports return to it as
+// soon as it recovers. When a meter provider is given, the exporter.failover.exports counter
+// counts the exports by the exporter.failover.target attribute: primary, secondary, or none
+// when both exporters failed.
+//
+// Each exporter gets its own timeout: the primary within the export context, and the
+// secondary a fresh one, so a primary using up the export context, e.g. by retrying, does
+// not leave the secondary with a context that is already done. The primary should not retry
+// for longer than the timeout, or the secondary is only tried once the primary gives up.
+//
+// Example usage:
+//
+//	failover, err := exporter.NewFailover(primaryExporter, secondaryExporter, 10*time.Second, otel.GetMeterProvider())
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(failover))
+//
+// Parameters:
+//   - primary: The exporter receiving the spans first
+//   - secondary: The exporter receiving the spans the primary failed to export
+//   - timeout: The timeout of the export of each exporter, zero for DefaultFailoverTimeout
+//   - provider: The meter provider used to create the counter, nil to disable it
+//
+// Returns:
+//   - *Failover: The failover exporter
+//   - error: Any error encountered while creating the counter
+func NewFailover(primary, secondary sdktrace.SpanExporter, timeout time.Duration, provider metric.MeterProvider) (*Failover, error) {
+	if timeout <= 0 {
+		timeout = DefaultFailoverTimeout
+	}
+
+	f := &Failover{primary: primary, secondary: secondary, timeout: timeout}
+
+	if provider == nil {
+		return f, nil
+	}
+
+	exports, err := provider.Meter(meterName).Int64Counter(
+		"exporter.failover.exports",
+		metric.WithDescription("Number of span exports by the exporter that delivered them"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	f.exports = exports
+
+	return f, nil
+}
+
+// ExportSpans exports the spans through the primary exporter, or the secondary one when the
+// primary fails. The errors of both exporters are returned when both fail.
+func (f *Failover) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	primaryCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	primaryErr := f.primary.ExportSpans(primaryCtx, spans)
+	cancel()
+
+	if primaryErr == nil {
+		f.record(ctx, "primary")
+		return nil
+	}
+
+	// The primary may have failed because the export context is done, which must not
+	// prevent the secondary from delivering the spans.
+	secondaryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), f.timeout)
+	defer cancel()
+
+	secondaryErr := f.secondary.ExportSpans(secondaryCtx, spans)
+	if secondaryErr == nil {
+		f.record(ctx, "secondary")
+		return nil
+	}
+
+	f.record(ctx, "none")
+
+	return errors.Join(primaryErr, secondaryErr)
+}
+
+// Shutdown shuts down both exporters.
+func (f *Failover) Shutdown(ctx context.Context) error {
+	return errors.Join(f.primary.Shutdown(ctx), f.secondary.Shutdown(ctx))
+}
+
+// record counts an export delivered by the given target.
+//
+// Parameters:
+//   - ctx: The context of the export
+//   - target: The exporter that delivered the spans
+func (f *Failover) record(ctx context.Context, target string) {
+	if f.exports != nil {
+		f.exports.Add(ctx, 1, metric.WithAttributes(FailoverTargetKey.String(target)))
+	}
+}
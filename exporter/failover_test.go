@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// testSpans are the spans exported by the tests.
+var testSpans = tracetest.SpanStubs{{Name: "orders.create"}, {Name: "orders.pay"}}.Snapshots()
+
+// exportCounts returns the failover export counter by target.
+func exportCounts(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			data, _ := m.Data.(metricdata.Sum[int64])
+			for _, point := range data.DataPoints {
+				target, _ := point.Attributes.Value(FailoverTargetKey)
+				counts[target.AsString()] += point.Value
+			}
+		}
+	}
+
+	return counts
+}
+
+func TestFailoverDeliversToTheSecondaryWhenThePrimaryFails(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	primary := &switchExporter{err: errUnavailable}
+	secondary := tracetest.NewInMemoryExporter()
+
+	failover, err := NewFailover(primary, secondary, 0, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("NewFailover: %v", err)
+	}
+
+	if err := failover.ExportSpans(context.Background(), testSpans); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	if got := len(secondary.GetSpans()); got != len(testSpans) {
+		t.Errorf("the secondary received %d spans, want %d", got, len(testSpans))
+	}
+
+	primary.set(nil)
+
+	if err := failover.ExportSpans(context.Background(), testSpans); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	if got := len(secondary.GetSpans()); got != len(testSpans) || primary.calls() != 2 {
+		t.Error("the export did not return to the recovered primary")
+	}
+
+	if counts := exportCounts(t, reader); counts["primary"] != 1 || counts["secondary"] != 1 {
+		t.Errorf("exports by target = %v, want one by each exporter", counts)
+	}
+}
+
+func TestFailoverJoinsTheErrorsWhenBothFail(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	errSecondary := errors.New("secondary unavailable")
+
+	failover, err := NewFailover(&switchExporter{err: errUnavailable}, &switchExporter{err: errSecondary}, 0, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("NewFailover: %v", err)
+	}
+
+	err = failover.ExportSpans(context.Background(), testSpans)
+	if !errors.Is(err, errUnavailable) || !errors.Is(err, errSecondary) {
+		t.Errorf("ExportSpans = %v, want the errors of both exporters", err)
+	}
+
+	if counts := exportCounts(t, reader); counts["none"] != 1 {
+		t.Errorf("exports by target = %v, want one delivered by none", counts)
+	}
+}
+
+// contextExporter fails its exports when their context is done.
+type contextExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+// ExportSpans exports the spans unless the context is done.
+func (e contextExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func TestFailoverGivesTheSecondaryAFreshContext(t *testing.T) {
+	primary := contextExporter{tracetest.NewInMemoryExporter()}
+	secondary := contextExporter{tracetest.NewInMemoryExporter()}
+
+	failover, err := NewFailover(primary, secondary, 0, nil)
+	if err != nil {
+		t.Fatalf("NewFailover: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := failover.ExportSpans(ctx, testSpans); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	if len(secondary.GetSpans()) != len(testSpans) {
+		t.Error("the secondary did not deliver the spans after the export context was done")
+	}
+}
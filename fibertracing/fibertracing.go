@@ -0,0 +1,256 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package fibertracing provides tracing middleware for the Fiber web framework.
+// Fiber is built on fasthttp rather than net/http, so the otelhttp instrumentation
+// cannot be used; this package implements a TextMapCarrier over fasthttp request
+// headers to continue inbound traces and starts a server span per request named
+// after the matched route.
+package fibertracing
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/goxkit/tracing"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used for the tracer
+	instrumentationName = "github.com/goxkit/tracing/fibertracing"
+)
+
+// RequestHeaderCarrier wraps fasthttp request headers to implement the TextMapCarrier
+// interface for OpenTelemetry propagation.
+type RequestHeaderCarrier struct {
+	// Header is the wrapped fasthttp request header
+	Header *fasthttp.RequestHeader
+}
+
+// Get retrieves the value for a given key from the request headers.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Parameters:
+//   - key: The header key to retrieve (case-insensitive)
+//
+// Returns:
+//   - string: The header value, or empty string if not found
+func (c RequestHeaderCarrier) Get(key string) string {
+	return string(c.Header.Peek(key))
+}
+
+// Set sets the value for the given key in the request headers.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Parameters:
+//   - key: The header key
+//   - val: The header value to set
+func (c RequestHeaderCarrier) Set(key, val string) {
+	c.Header.Set(key, val)
+}
+
+// Keys returns a sorted list of all keys in the request headers.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Returns:
+//   - []string: A sorted slice of all header keys
+func (c RequestHeaderCarrier) Keys() []string {
+	keys := []string{}
+
+	c.Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// routePattern is a registered route with its path compiled to a regular expression.
+type routePattern struct {
+	// method is the HTTP method of the route
+	method string
+
+	// path is the registered route path, e.g. /users/:id
+	path string
+
+	// pattern matches the request paths served by the route
+	pattern *regexp.Regexp
+}
+
+// compileRoute compiles a Fiber route path to a regular expression matching the request
+// paths it serves, following the default case-insensitive and non-strict routing: named
+// parameters (:id, optionally with a constraint) match a path segment, optional ones
+// (:id?) may be absent, and the * and + wildcards match the rest of the path.
+//
+// Parameters:
+//   - path: The registered route path
+//
+// Returns:
+//   - *regexp.Regexp: The regular expression matching the request paths
+func compileRoute(path string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+
+	for i := 0; i < len(path); i++ {
+		switch ch := path[i]; ch {
+		case ':':
+			j := i + 1
+			for j < len(path) && path[j] != '/' && path[j] != '?' && path[j] != '<' && path[j] != '-' && path[j] != '.' {
+				j++
+			}
+
+			if j < len(path) && path[j] == '<' {
+				for j < len(path) && path[j] != '>' {
+					j++
+				}
+				j++
+			}
+
+			if j < len(path) && path[j] == '?' {
+				// An optional parameter may be absent together with its leading slash.
+				if strings.HasSuffix(b.String(), "/") {
+					trimmed := strings.TrimSuffix(b.String(), "/")
+					b.Reset()
+					b.WriteString(trimmed)
+				}
+				b.WriteString("(?:/[^/]+)?")
+				j++
+			} else {
+				b.WriteString("[^/]+")
+			}
+
+			i = j - 1
+		case '*':
+			b.WriteString(".*")
+		case '+':
+			b.WriteString(".+")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	b.WriteString("/?$")
+
+	return regexp.MustCompile(b.String())
+}
+
+// routeResolver finds the route a request is served by before the handlers run, so the
+// server span can be named after it when it starts.
+type routeResolver struct {
+	// once guards the compilation of the routes
+	once sync.Once
+
+	// routes are the routes of the application, in registration order
+	routes []routePattern
+}
+
+// resolve returns the path of the first route registered for the request method whose path
+// matches the request path, as Fiber does.
+//
+// Parameters:
+//   - c: The request context
+//
+// Returns:
+//   - string: The matching route path
+//   - bool: Whether a route matches the request
+func (r *routeResolver) resolve(c *fiber.Ctx) (string, bool) {
+	r.once.Do(func() {
+		for _, route := range c.App().GetRoutes(true) {
+			r.routes = append(r.routes, routePattern{method: route.Method, path: route.Path, pattern: compileRoute(route.Path)})
+		}
+	})
+
+	method, path := c.Method(), c.Path()
+	for _, route := range r.routes {
+		if route.method == method && route.pattern.MatchString(path) {
+			return route.path, true
+		}
+	}
+
+	return "", false
+}
+
+// Middleware returns a Fiber middleware that traces each request with a server span.
+// The inbound trace context is extracted from the fasthttp headers with the globally
+// configured propagator, the span is started with the tracer provider installed by the
+// tracing package and named after the matched route (e.g. /users/:id), resolved from the
+// routes of the application when the span starts so samplers see the route name and the
+// http.route attribute, and the span context is stored in c.UserContext() for the
+// handlers. Errors returned by the handlers are recorded on the span and passed to the
+// application error handler.
+//
+// Example usage:
+//
+//	app := fiber.New()
+//	app.Use(fibertracing.Middleware())
+//
+// Returns:
+//   - fiber.Handler: The tracing middleware
+func Middleware() fiber.Handler {
+	resolver := &routeResolver{}
+
+	return func(c *fiber.Ctx) error {
+		carrier := RequestHeaderCarrier{Header: &c.Request().Header}
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), carrier)
+
+		name := fmt.Sprintf("HTTP %s", c.Method())
+		attrs := []attribute.KeyValue{attribute.String("http.method", c.Method())}
+
+		route, resolved := resolver.resolve(c)
+		if resolved {
+			name = route
+			attrs = append(attrs, attribute.String("http.route", route))
+		}
+
+		ctx, span := tracing.Tracer(instrumentationName).Start(
+			ctx,
+			name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		var handlerErr error
+		if err := c.Next(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			// The error handler writes the response status, which must be known
+			// before the span ends, so it is invoked here instead of by Fiber.
+			handlerErr = c.App().Config().ErrorHandler(c, err)
+		}
+
+		status := c.Response().StatusCode()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+
+		// Routes that could not be resolved upfront are only known once the handlers ran.
+		if !resolved {
+			route = c.Route().Path
+			span.SetName(route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return handlerErr
+	}
+}
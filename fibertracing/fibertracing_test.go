@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package fibertracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceparent is the trace context sent by the caller in the tests.
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// nameSampler samples every span, keeping the names and attributes the spans start with.
+type nameSampler struct {
+	// mu guards the fields below
+	mu sync.Mutex
+
+	// names are the names of the sampled spans
+	names []string
+
+	// attributes are the start attributes of the sampled spans
+	attributes [][]attribute.KeyValue
+}
+
+// ShouldSample records the span name and attributes and samples the span.
+func (s *nameSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.names = append(s.names, p.Name)
+	s.attributes = append(s.attributes, p.Attributes)
+
+	return sdktrace.AlwaysSample().ShouldSample(p)
+}
+
+// Description describes the sampler.
+func (s *nameSampler) Description() string {
+	return "nameSampler"
+}
+
+// newTestApp returns a traced Fiber application, with a recorder of the ended spans, the
+// sampler seeing the started spans and the W3C trace context propagator installed globally
+// until the test ends.
+func newTestApp(t *testing.T) (*fiber.App, *tracetest.SpanRecorder, *nameSampler) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	sampler := &nameSampler{}
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	app := fiber.New()
+	app.Use(Middleware())
+
+	return app, recorder, sampler
+}
+
+// serve runs the request through the application.
+func serve(t *testing.T, app *fiber.App, req *http.Request) *http.Response {
+	t.Helper()
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	return resp
+}
+
+// containsAttribute reports whether the attributes hold the given attribute.
+func containsAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestCompileRoute(t *testing.T) {
+	tests := []struct {
+		route string
+		match []string
+		miss  []string
+	}{
+		{route: "/users/:id", match: []string{"/users/42", "/USERS/42", "/users/42/"}, miss: []string{"/users", "/users/42/orders"}},
+		{route: "/users/:id?", match: []string{"/users", "/users/42"}, miss: []string{"/users/42/orders"}},
+		{route: "/users/:id<int>/orders", match: []string{"/users/42/orders"}, miss: []string{"/users/orders"}},
+		{route: "/files/*", match: []string{"/files/", "/files/a/b.txt"}, miss: []string{"/other"}},
+		{route: "/files/+", match: []string{"/files/a"}, miss: []string{"/files/"}},
+		{route: "/flights/:from-:to", match: []string{"/flights/LAX-SFO"}, miss: []string{"/flights/LAX"}},
+	}
+
+	for _, tt := range tests {
+		pattern := compileRoute(tt.route)
+
+		for _, path := range tt.match {
+			if !pattern.MatchString(path) {
+				t.Errorf("%s does not match %s", tt.route, path)
+			}
+		}
+
+		for _, path := range tt.miss {
+			if pattern.MatchString(path) {
+				t.Errorf("%s matches %s", tt.route, path)
+			}
+		}
+	}
+}
+
+func TestMiddlewareNamesSpanAfterRouteWhenItStarts(t *testing.T) {
+	app, recorder, sampler := newTestApp(t)
+
+	var handlerSpan trace.SpanContext
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		handlerSpan = trace.SpanContextFromContext(c.UserContext())
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	serve(t, app, req)
+
+	if len(sampler.names) != 1 || sampler.names[0] != "/users/:id" {
+		t.Errorf("sampled names = %v, want [/users/:id]", sampler.names)
+	}
+
+	if !containsAttribute(sampler.attributes[0], attribute.String("http.route", "/users/:id")) {
+		t.Errorf("start attributes = %v, want http.route=/users/:id", sampler.attributes[0])
+	}
+
+	span := recorder.Ended()[0]
+	if span.Name() != "/users/:id" || span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span = %s (%s), want /users/:id (server)", span.Name(), span.SpanKind())
+	}
+
+	if got := span.Parent().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("parent trace = %s, want the trace of the request", got)
+	}
+
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("the handler context does not hold the server span")
+	}
+
+	if !containsAttribute(span.Attributes(), attribute.Int("http.status_code", http.StatusNoContent)) {
+		t.Error("http.status_code=204 missing")
+	}
+}
+
+func TestMiddlewareRecordsHandlerErrors(t *testing.T) {
+	app, recorder, _ := newTestApp(t)
+
+	app.Get("/orders", func(*fiber.Ctx) error {
+		return fiber.NewError(http.StatusServiceUnavailable, "maintenance")
+	})
+
+	if resp := serve(t, app, httptest.NewRequest(http.MethodGet, "/orders", nil)); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("response status = %d, want 503", resp.StatusCode)
+	}
+
+	span := recorder.Ended()[0]
+	if !containsAttribute(span.Attributes(), attribute.Int("http.status_code", http.StatusServiceUnavailable)) {
+		t.Error("http.status_code=503 missing")
+	}
+
+	if span.Status().Code != codes.Error || len(span.Events()) != 1 {
+		t.Errorf("span status = %v with %d events, want Error with the recorded error", span.Status(), len(span.Events()))
+	}
+}
+
+func TestMiddlewareRenamesUnresolvedRequests(t *testing.T) {
+	app, recorder, sampler := newTestApp(t)
+
+	app.Get("/orders", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	serve(t, app, httptest.NewRequest(http.MethodPost, "/missing", nil))
+
+	if sampler.names[0] != "HTTP POST" {
+		t.Errorf("start name = %q, want HTTP POST", sampler.names[0])
+	}
+
+	span := recorder.Ended()[0]
+	if want := "/"; span.Name() != want {
+		t.Errorf("span name = %q, want the route of the middleware %q", span.Name(), want)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package file provides file-based exporting capabilities for the tracing package. It writes
+// trace data to a local file as newline-delimited OTLP JSON, for air-gapped environments and
+// post-mortem analysis where spans are captured offline and uploaded later to a collector.
+package file
+
+import (
+	"context"
+	"os"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/internal/provider"
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// PathEnvKey is the environment variable setting the path of the spans file
+	PathEnvKey = "TRACING_FILE_PATH"
+
+	// DefaultPath is the path of the spans file when none is configured
+	DefaultPath = "traces.ndjson"
+)
+
+// client is an OTLP trace client writing each batch of spans, already transformed to the
+// OTLP protobuf model by the otlptrace exporter, to a rotating file as an
+// ExportTraceServiceRequest in the protobuf JSON encoding, one request per line.
+type client struct {
+	// writer is the rotating file the requests are written to
+	writer *rotatingWriter
+}
+
+// Start does nothing, the file is opened when the client is created.
+func (c *client) Start(context.Context) error {
+	return nil
+}
+
+// Stop releases the file, closing it once no other provider writes to it.
+func (c *client) Stop(context.Context) error {
+	return c.writer.release()
+}
+
+// UploadTraces writes the spans as a single ExportTraceServiceRequest line.
+func (c *client) UploadTraces(_ context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	line, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.writer.Write(append(line, '\n'))
+
+	return err
+}
+
+// Install configures and initializes an OpenTelemetry tracer provider that appends the ended
+// spans to a file, each exported batch on its own line as an OTLP ExportTraceServiceRequest in
+// the protobuf JSON encoding, which OTLP/HTTP collectors accept, so the file can be replayed.
+// The path is set with options.WithFilePath and defaults to the TRACING_FILE_PATH environment
+// variable, then to traces.ndjson; when a size limit is set with options.WithFileMaxSize, the
+// file is renamed with a timestamp once full and a new one is started. The provider is
+// configured with the same sampling, span limits, processors and resource attributes as the
+// OTLP installer, and is registered in the configs and as the global tracer provider.
+// Providers writing to the same path share one writer, so their lines never interleave and
+// the file is rotated once.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the file path and size limit
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The configured tracer provider with file export
+//   - error: Any error encountered during setup
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := NewProvider(cfgs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.Register(cfgs, options.New(opts...), tracerProvider)
+
+	return tracerProvider, nil
+}
+
+// NewProvider creates a tracer provider writing spans to a file exactly like Install,
+// without registering it in the configs nor as the global tracer provider.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the file path and size limit
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The tracer provider with file export
+//   - error: Any error encountered during setup
+func NewProvider(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	o := options.New(opts...)
+
+	writer, err := acquireWriter(path(o), o.FileMaxSize)
+	if err != nil {
+		cfgs.Logger.Error("failed to open trace file", zap.Error(err))
+		return nil, err
+	}
+
+	exp, err := otlptrace.New(context.Background(), &client{writer: writer})
+	if err != nil {
+		_ = writer.release()
+		cfgs.Logger.Error("failed to create file trace exporter", zap.Error(err))
+		return nil, err
+	}
+
+	return provider.New(cfgs, o, exp)
+}
+
+// path resolves the path of the spans file from the options, the TRACING_FILE_PATH
+// environment variable and the default, in that order.
+//
+// Parameters:
+//   - o: The installation options
+//
+// Returns:
+//   - string: The path of the spans file
+func path(o *options.Config) string {
+	if o.FilePath != "" {
+		return o.FilePath
+	}
+
+	if p := os.Getenv(PathEnvKey); p != "" {
+		return p
+	}
+
+	return DefaultPath
+}
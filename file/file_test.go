@@ -0,0 +1,100 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package file
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestNewProviderWritesOTLPJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces", "spans.ndjson")
+	cfgs := &configs.Configs{Logger: zap.NewNop(), AppConfigs: &configs.AppConfigs{Name: "orders"}}
+
+	tp, err := NewProvider(cfgs, options.WithFilePath(path))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	names := []string{"orders.create", "orders.pay", "orders.ship"}
+	for _, name := range names {
+		_, span := tp.Tracer("test").Start(context.Background(), name)
+		span.End()
+	}
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening the trace file: %v", err)
+	}
+	defer f.Close()
+
+	var got []string
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var req coltracepb.ExportTraceServiceRequest
+		if err := protojson.Unmarshal(scanner.Bytes(), &req); err != nil {
+			t.Fatalf("line is not an OTLP JSON export request: %v", err)
+		}
+
+		for _, rs := range req.ResourceSpans {
+			var service string
+			for _, kv := range rs.Resource.GetAttributes() {
+				if kv.Key == "service.name" {
+					service = kv.Value.GetStringValue()
+				}
+			}
+
+			if service != "orders" {
+				t.Errorf("service.name = %q, want orders", service)
+			}
+
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					got = append(got, span.Name)
+				}
+			}
+		}
+	}
+
+	if len(got) != len(names) {
+		t.Fatalf("got spans %v, want %v", got, names)
+	}
+
+	for i := range names {
+		if got[i] != names[i] {
+			t.Errorf("span %d = %s, want %s", i, got[i], names[i])
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(PathEnvKey, "")
+
+	if got := path(options.New()); got != DefaultPath {
+		t.Errorf("default path = %s, want %s", got, DefaultPath)
+	}
+
+	t.Setenv(PathEnvKey, "/var/log/traces.ndjson")
+
+	if got := path(options.New()); got != "/var/log/traces.ndjson" {
+		t.Errorf("path = %s, want the environment value", got)
+	}
+
+	if got := path(options.New(options.WithFilePath("spans.ndjson"))); got != "spans.ndjson" {
+		t.Errorf("path = %s, want the option value", got)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rotationTimeFormat is the timestamp inserted in the name of rotated files
+	rotationTimeFormat = "20060102T150405.000000000"
+)
+
+var (
+	// writersMu guards writers
+	writersMu sync.Mutex
+
+	// writers holds the open writers indexed by cleaned path, so providers writing to the
+	// same file (e.g. tenant providers) share one writer instead of rotating it concurrently
+	writers = map[string]*rotatingWriter{}
+)
+
+// rotatingWriter appends to a file and, when a size limit is set, moves the file aside
+// once the next write would exceed the limit, so each file stays below the limit.
+// The file client issues a single write per line, so lines are never split. When a rotation
+// fails, the writer keeps appending to the file at path and retries rotating on the next write.
+type rotatingWriter struct {
+	// mu serializes writes, rotations and close
+	mu sync.Mutex
+
+	// path is the path of the file being written
+	path string
+
+	// maxSize is the size in bytes triggering a rotation, zero to never rotate
+	maxSize int64
+
+	// file is the open file, nil when reopening it after a rotation failed
+	file *os.File
+
+	// closed reports whether Close was called
+	closed bool
+
+	// size is the current size of the open file
+	size int64
+
+	// refs is the number of providers using the writer, guarded by writersMu
+	refs int
+}
+
+// acquireWriter returns the writer of the file at path, opening it when no provider uses it
+// yet. Every acquired writer must be released with release.
+//
+// Parameters:
+//   - path: The path of the file to write
+//   - maxSize: The size in bytes triggering a rotation, zero to never rotate; ignored when the
+//     file is already open, the first provider's limit applies
+//
+// Returns:
+//   - *rotatingWriter: The shared writer
+//   - error: Any error encountered while opening the file
+func acquireWriter(path string, maxSize int64) (*rotatingWriter, error) {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	key := filepath.Clean(path)
+	if w, ok := writers[key]; ok {
+		w.refs++
+		return w, nil
+	}
+
+	w, err := newRotatingWriter(path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	w.refs = 1
+	writers[key] = w
+
+	return w, nil
+}
+
+// release gives back a writer returned by acquireWriter, closing the file once no provider
+// uses it anymore.
+//
+// Returns:
+//   - error: Any error encountered while closing the file
+func (w *rotatingWriter) release() error {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	if w.refs == 0 {
+		return nil
+	}
+
+	w.refs--
+	if w.refs > 0 {
+		return nil
+	}
+
+	delete(writers, filepath.Clean(w.path))
+
+	return w.Close()
+}
+
+// newRotatingWriter opens, creating it and its directory when missing, the file at path.
+//
+// Parameters:
+//   - path: The path of the file to write
+//   - maxSize: The size in bytes triggering a rotation, zero to never rotate
+//
+// Returns:
+//   - *rotatingWriter: The writer
+//   - error: Any error encountered while opening the file
+func newRotatingWriter(path string, maxSize int64) (*rotatingWriter, error) {
+	w := &rotatingWriter{path: path, maxSize: maxSize}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write appends p to the file, rotating it first when p would exceed the size limit.
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+// Close closes the file. Writes after Close fail.
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+
+	return err
+}
+
+// open opens the file in append mode and records its current size.
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	w.file = f
+	w.size = info.Size()
+
+	return nil
+}
+
+// rotate closes the file, renames it with the rotation time inserted before its extension,
+// e.g. traces-20251016T101500.000000000.ndjson, and opens a new file at the original path.
+// When the rename fails the file at path is reopened, and when reopening fails the next
+// write tries again, so a failed rotation never stops the writes for good.
+func (w *rotatingWriter) rotate() error {
+	closeErr := w.file.Close()
+	w.file = nil
+
+	if closeErr != nil {
+		return closeErr
+	}
+
+	ext := filepath.Ext(w.path)
+	rotated := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(w.path, ext), time.Now().UTC().Format(rotationTimeFormat), ext)
+
+	renameErr := os.Rename(w.path, rotated)
+
+	return errors.Join(renameErr, w.open())
+}
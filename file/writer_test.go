@@ -0,0 +1,133 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingWriterRotatesPastTheSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "spans.ndjson")
+
+	w, err := newRotatingWriter(path, 10)
+	if err != nil {
+		t.Fatalf("newRotatingWriter: %v", err)
+	}
+	t.Cleanup(func() { _ = w.Close() })
+
+	for _, line := range []string{"first\n", "second\n", "a line longer than the limit\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading the directory: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("got %d files, want the current file and 2 rotated ones", len(entries))
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the current file: %v", err)
+	}
+
+	if string(current) != "a line longer than the limit\n" {
+		t.Errorf("current file = %q, want the last line only", current)
+	}
+}
+
+func TestRotatingWriterRecoversFromAFailedRotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "traces")
+	path := filepath.Join(dir, "spans.ndjson")
+
+	w, err := newRotatingWriter(path, 10)
+	if err != nil {
+		t.Fatalf("newRotatingWriter: %v", err)
+	}
+	t.Cleanup(func() { _ = w.Close() })
+
+	if _, err := w.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Without the directory, both the rename and the reopening of the file fail.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("removing the directory: %v", err)
+	}
+
+	if _, err := w.Write([]byte("second\n")); err == nil {
+		t.Fatal("Write succeeded though the rotation failed")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("restoring the directory: %v", err)
+	}
+
+	if _, err := w.Write([]byte("third\n")); err != nil {
+		t.Fatalf("Write after the directory was restored: %v", err)
+	}
+
+	if got, _ := os.ReadFile(path); string(got) != "third\n" {
+		t.Errorf("file = %q, want the line written after the failed rotation", got)
+	}
+}
+
+func TestRotatingWriterAppendsWithoutLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.ndjson")
+	if err := os.WriteFile(path, []byte("existing\n"), 0o644); err != nil {
+		t.Fatalf("writing the file: %v", err)
+	}
+
+	w, err := newRotatingWriter(path, 0)
+	if err != nil {
+		t.Fatalf("newRotatingWriter: %v", err)
+	}
+
+	_, _ = w.Write([]byte("appended\n"))
+	_ = w.Close()
+
+	if _, err := w.Write([]byte("closed\n")); err != os.ErrClosed {
+		t.Errorf("Write after Close error = %v, want os.ErrClosed", err)
+	}
+
+	if got, _ := os.ReadFile(path); string(got) != "existing\nappended\n" {
+		t.Errorf("file = %q, want the line appended", got)
+	}
+}
+
+func TestAcquireWriterSharesWritersPerPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.ndjson")
+
+	first, err := acquireWriter(path, 0)
+	if err != nil {
+		t.Fatalf("acquireWriter: %v", err)
+	}
+
+	second, err := acquireWriter(filepath.Join(filepath.Dir(path), ".", "spans.ndjson"), 0)
+	if err != nil {
+		t.Fatalf("acquireWriter: %v", err)
+	}
+
+	if first != second {
+		t.Fatal("the writers of the same path are not shared")
+	}
+
+	_ = first.release()
+	if _, err := second.Write([]byte("still open\n")); err != nil {
+		t.Errorf("Write after the first release: %v", err)
+	}
+
+	_ = second.release()
+	if _, err := second.Write([]byte("closed\n")); err != os.ErrClosed {
+		t.Errorf("Write after the last release error = %v, want os.ErrClosed", err)
+	}
+}
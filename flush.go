@@ -0,0 +1,65 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flusher is implemented by tracer providers able to export their pending spans on demand,
+// such as *sdktrace.TracerProvider.
+type flusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
+// FlushSpan exports the ended spans pending in the tracer provider of the span found in the
+// context, and returns once they are exported or the context is done. It lets synchronous
+// flows, such as end-to-end tests asserting on exported data, see the spans of a request
+// right away. The flush is scoped to the provider that created the span, e.g. a tenant
+// provider, but covers every pending span of that provider, as exporters export in batches.
+// Spans still open, including the one of the context, are not exported: end them first.
+// Without a span created by the SDK in the context, the installed tracer provider is flushed.
+//
+// Example usage:
+//
+//	span.End()
+//	if err := tracing.FlushSpan(ctx); err != nil {
+//		logger.Warn("failed to flush spans", zap.Error(err))
+//	}
+//
+// Parameters:
+//   - ctx: The context containing the span, also bounding the flush
+//
+// Returns:
+//   - error: Any error encountered while exporting, or the context error on timeout
+func FlushSpan(ctx context.Context) error {
+	if f, ok := trace.SpanFromContext(ctx).TracerProvider().(flusher); ok {
+		return f.ForceFlush(ctx)
+	}
+
+	if f, ok := installedProvider().(flusher); ok {
+		return f.ForceFlush(ctx)
+	}
+
+	return nil
+}
+
+// installedProvider returns the tracer provider stored in the configs passed to Install,
+// or the global tracer provider when Install has not been called or the configs hold none.
+//
+// Returns:
+//   - trace.TracerProvider: The installed tracer provider
+func installedProvider() trace.TracerProvider {
+	if cfgs := installedConfigs.Load(); cfgs != nil {
+		if installed, ok := cfgs.TracerProvider.(trace.TracerProvider); ok {
+			return installed
+		}
+	}
+
+	return otel.GetTracerProvider()
+}
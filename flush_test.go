@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goxkit/configs"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// installBatchingProvider stores configs holding a tracer provider that exports its spans in
+// batches sent long after the spans end, as Install does, until the test ends.
+func installBatchingProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp, sdktrace.WithBatchTimeout(time.Hour)))
+
+	previous := installedConfigs.Swap(&configs.Configs{TracerProvider: tp})
+	t.Cleanup(func() {
+		installedConfigs.Store(previous)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	return tp, exp
+}
+
+func TestFlushSpanExportsTheEndedSpan(t *testing.T) {
+	tp, exp := installBatchingProvider(t)
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if len(exp.GetSpans()) != 0 {
+		t.Fatal("the span was exported before the flush")
+	}
+
+	if err := FlushSpan(ctx); err != nil {
+		t.Fatalf("FlushSpan: %v", err)
+	}
+
+	if spans := exp.GetSpans(); len(spans) != 1 || spans[0].Name != "orders.create" {
+		t.Errorf("got %d exported spans after the flush, want orders.create", len(spans))
+	}
+}
+
+func TestFlushSpanWithoutSpanFlushesTheInstalledProvider(t *testing.T) {
+	tp, exp := installBatchingProvider(t)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if err := FlushSpan(context.Background()); err != nil {
+		t.Fatalf("FlushSpan: %v", err)
+	}
+
+	if len(exp.GetSpans()) != 1 {
+		t.Errorf("got %d exported spans after the flush, want 1", len(exp.GetSpans()))
+	}
+}
+
+func TestFlushSpanReturnsTheContextError(t *testing.T) {
+	tp, _ := installBatchingProvider(t)
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if err := FlushSpan(ctx); err == nil {
+		t.Error("FlushSpan with a canceled context returned no error")
+	}
+}
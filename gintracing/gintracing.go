@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package gintracing provides tracing middleware for the Gin web framework.
+// The middleware continues inbound traces using the globally configured propagator
+// and starts a server span per request named after the matched route template,
+// keeping span names low-cardinality regardless of the path parameters.
+package gintracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goxkit/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used for the tracer
+	instrumentationName = "github.com/goxkit/tracing/gintracing"
+)
+
+// Middleware returns a Gin middleware that traces each request with a server span.
+// The span is named after the route template (e.g. /users/:id) rather than the raw path,
+// carries the http.method, http.route and http.status_code attributes, and records the
+// errors attached to the Gin context. Spans are started with the tracer provider installed
+// by the tracing package. The span context is made available to handlers through
+// c.Request.Context().
+//
+// Example usage:
+//
+//	router := gin.New()
+//	router.Use(gintracing.Middleware())
+//
+// Returns:
+//   - gin.HandlerFunc: The tracing middleware
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		spanName := route
+		if spanName == "" {
+			spanName = fmt.Sprintf("HTTP %s", c.Request.Method)
+		}
+
+		ctx, span := tracing.Tracer(instrumentationName).Start(
+			ctx,
+			spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		} else if len(c.Errors) > 0 {
+			span.SetStatus(codes.Error, c.Errors.Last().Error())
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package gintracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceparent is the trace context sent by the caller in the tests.
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newTestRouter returns a traced router, with a recorder of the ended spans and the W3C
+// trace context propagator installed globally until the test ends.
+func newTestRouter(t *testing.T) (*gin.Engine, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+
+	return router, recorder
+}
+
+func TestMiddlewareNamesSpanAfterRoute(t *testing.T) {
+	router, recorder := newTestRouter(t)
+
+	var handlerSpan trace.SpanContext
+	router.GET("/users/:id", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "/users/:id" || span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span = %s (%s), want /users/:id (server)", span.Name(), span.SpanKind())
+	}
+
+	if got := span.Parent().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" || !span.Parent().IsRemote() {
+		t.Errorf("parent trace = %s, want the remote trace of the request", got)
+	}
+
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("the handler context does not hold the server span")
+	}
+
+	want := []attribute.KeyValue{
+		attribute.String("http.method", http.MethodGet),
+		attribute.String("http.route", "/users/:id"),
+		attribute.Int("http.status_code", http.StatusNoContent),
+	}
+	for _, kv := range want {
+		found := false
+		for _, got := range span.Attributes() {
+			found = found || got == kv
+		}
+
+		if !found {
+			t.Errorf("attribute %s=%s missing", kv.Key, kv.Value.Emit())
+		}
+	}
+}
+
+func TestMiddlewareRecordsErrors(t *testing.T) {
+	router, recorder := newTestRouter(t)
+
+	router.GET("/fail", func(c *gin.Context) {
+		_ = c.Error(errors.New("validation failed"))
+		c.Status(http.StatusBadRequest)
+	})
+	router.GET("/crash", func(c *gin.Context) {
+		c.Status(http.StatusBadGateway)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/crash", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "validation failed" {
+		t.Errorf("failed request status = %v, want the error of the context", status)
+	}
+
+	if len(spans[0].Events()) != 1 {
+		t.Errorf("got %d events, want the recorded error", len(spans[0].Events()))
+	}
+
+	if status := spans[1].Status(); status.Code != codes.Error || status.Description != "Bad Gateway" {
+		t.Errorf("5xx status = %v, want Error Bad Gateway", status)
+	}
+}
+
+func TestMiddlewareNamesUnmatchedRequestsByMethod(t *testing.T) {
+	router, recorder := newTestRouter(t)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/missing", nil))
+
+	if got := recorder.Ended()[0].Name(); got != "HTTP POST" {
+		t.Errorf("span name = %q, want HTTP POST", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goxkit/tracing/otlp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var (
+	// errNoExporterConn is returned by Health when the OTLP exporter has no connection
+	errNoExporterConn = errors.New("otlp exporter has no connection")
+)
+
+// exporterInstall records the exporter selected by a call of Install.
+type exporterInstall struct {
+	// name is the name of the exporter, e.g. otlp
+	name string
+
+	// tracerProvider is the installed tracer provider, nil when the installation failed
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// ExporterReady reports, without blocking, whether the connection the tracer provider
+// installed by Install exports through is usable: connected, or idle and able to reconnect
+// on demand. This is the shared OTLP exporter connection of the configs, or the connection
+// dialed for a traces-specific endpoint or compression. It is false when the OTLP exporter
+// has no connection, e.g. because its installation failed or it was shut down, and true when
+// another exporter is installed, e.g. stdout or noop.
+//
+// Returns:
+//   - bool: Whether the exporter connection is ready
+func ExporterReady() bool {
+	conn, isOTLP := exporterConn()
+	if !isOTLP {
+		return true
+	}
+
+	if conn == nil {
+		return false
+	}
+
+	state := conn.GetState()
+
+	return state == connectivity.Ready || state == connectivity.Idle
+}
+
+// Health checks the connection the tracer provider installed by Install exports through,
+// for use in readiness probes. An idle connection is asked to connect, and a connection
+// being established is waited for until the context is done. It returns an error when the
+// OTLP exporter has no connection, and nil when another exporter is installed, e.g. stdout
+// or noop.
+//
+// Example usage:
+//
+//	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//		ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+//		defer cancel()
+//
+//		if err := tracing.Health(ctx); err != nil {
+//			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//		}
+//	})
+//
+// Parameters:
+//   - ctx: Context bounding the wait for a connection being established
+//
+// Returns:
+//   - error: An error describing the connection state when it is not ready
+func Health(ctx context.Context) error {
+	conn, isOTLP := exporterConn()
+	if !isOTLP {
+		return nil
+	}
+
+	if conn == nil {
+		return errNoExporterConn
+	}
+
+	for {
+		state := conn.GetState()
+
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			conn.Connect()
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("otlp exporter connection is %s", state)
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("otlp exporter connection is %s: %w", state, ctx.Err())
+		}
+	}
+}
+
+// exporterConn returns the connection the tracer provider installed by Install exports
+// through, when the OTLP exporter is installed.
+//
+// Returns:
+//   - *grpc.ClientConn: The exporter connection, nil when the OTLP exporter has none
+//   - bool: Whether the OTLP exporter is installed
+func exporterConn() (*grpc.ClientConn, bool) {
+	installed := installedExporter.Load()
+	if installed == nil || installed.name != OTLPExporter {
+		return nil, false
+	}
+
+	if installed.tracerProvider == nil {
+		return nil, true
+	}
+
+	conn, _ := otlp.ExporterConn(installed.tracerProvider)
+
+	return conn, true
+}
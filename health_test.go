@@ -0,0 +1,148 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/goxkit/tracing/options"
+	"github.com/goxkit/tracing/otlp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+// installOTLPExporter records an OTLP tracer provider exporting to the endpoint as installed
+// by Install until the test ends.
+func installOTLPExporter(t *testing.T, endpoint string, opts ...options.Option) *sdktrace.TracerProvider {
+	t.Helper()
+	restoreInstall(t)
+
+	cfgs := newTestConfigs(true)
+	cfgs.OTLPConfigs.Endpoint = endpoint
+
+	tp, err := otlp.NewProvider(cfgs, opts...)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		if cfgs.OTLPExporterConn != nil {
+			_ = cfgs.OTLPExporterConn.Close()
+		}
+	})
+
+	installedExporter.Store(&exporterInstall{name: OTLPExporter, tracerProvider: tp})
+
+	return tp
+}
+
+// newCollectorAddr starts a gRPC server on a local port until the test ends and returns its address.
+func newCollectorAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+// closedAddr returns the address of a local port nothing listens on.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	addr := lis.Addr().String()
+	_ = lis.Close()
+
+	return addr
+}
+
+func TestHealthWithAReadyConnection(t *testing.T) {
+	installOTLPExporter(t, newCollectorAddr(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	if !ExporterReady() {
+		t.Error("ExporterReady is false with a connected exporter")
+	}
+}
+
+func TestHealthWithAFailingConnection(t *testing.T) {
+	installOTLPExporter(t, closedAddr(t), options.WithLazyConnect(), options.WithSetupTimeout(100*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := Health(ctx); err == nil {
+		t.Fatal("Health succeeded without a collector")
+	}
+
+	if ExporterReady() {
+		t.Error("ExporterReady is true with a failing connection")
+	}
+}
+
+func TestHealthChecksTheTracesEndpointConnection(t *testing.T) {
+	t.Setenv(otlp.TracesEndpointEnvKey, closedAddr(t))
+	installOTLPExporter(t, newCollectorAddr(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := Health(ctx); err == nil {
+		t.Fatal("Health succeeded without a collector at the traces endpoint")
+	}
+}
+
+func TestHealthWithoutExporterConnection(t *testing.T) {
+	restoreInstall(t)
+	installedExporter.Store(&exporterInstall{name: OTLPExporter})
+
+	if err := Health(context.Background()); !errors.Is(err, errNoExporterConn) {
+		t.Errorf("Health error = %v, want errNoExporterConn", err)
+	}
+
+	if ExporterReady() {
+		t.Error("ExporterReady is true without a connection")
+	}
+
+	tp := installOTLPExporter(t, newCollectorAddr(t))
+	_ = tp.Shutdown(context.Background())
+
+	if err := Health(context.Background()); !errors.Is(err, errNoExporterConn) {
+		t.Errorf("Health error after shutdown = %v, want errNoExporterConn", err)
+	}
+}
+
+func TestHealthWithOtherExporters(t *testing.T) {
+	restoreInstall(t)
+
+	for _, installed := range []*exporterInstall{nil, {name: NoopExporter}, {name: StdoutExporter}} {
+		installedExporter.Store(installed)
+
+		if err := Health(context.Background()); err != nil || !ExporterReady() {
+			t.Errorf("Health = %v and ExporterReady = %t, want a healthy exporter without connection", err, ExporterReady())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package http provides utilities for tracing HTTP servers and clients.
+// It complements the OpenTelemetry otelhttp instrumentation with helpers that
+// correlate HTTP traffic with the active trace, such as structured access logs
+// that carry the trace and span identifiers of each request.
+package http
+
+import (
+	"net/http"
+	"time"
+
+	tracingzap "github.com/goxkit/tracing/zap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AccessLogField identifies a request attribute that can be included in an access log entry.
+type AccessLogField string
+
+const (
+	// FieldMethod logs the HTTP request method
+	FieldMethod AccessLogField = "method"
+
+	// FieldPath logs the request URL path
+	FieldPath AccessLogField = "path"
+
+	// FieldStatus logs the response status code
+	FieldStatus AccessLogField = "status"
+
+	// FieldDuration logs the time taken to serve the request
+	FieldDuration AccessLogField = "duration"
+
+	// FieldRemoteAddr logs the network address of the client
+	FieldRemoteAddr AccessLogField = "remote_addr"
+
+	// FieldUserAgent logs the User-Agent header sent by the client
+	FieldUserAgent AccessLogField = "user_agent"
+
+	// FieldBytes logs the number of response body bytes written
+	FieldBytes AccessLogField = "bytes"
+)
+
+var (
+	// DefaultAccessLogFields is the set of fields logged when AccessLog is called without fields.
+	DefaultAccessLogFields = []AccessLogField{FieldMethod, FieldPath, FieldStatus, FieldDuration}
+)
+
+// responseRecorder wraps an http.ResponseWriter to capture the status code and
+// the number of bytes written, which are only known after the handler returns.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	// status is the status code sent to the client
+	status int
+
+	// bytes is the number of body bytes written to the client
+	bytes int
+}
+
+// WriteHeader records the status code before delegating to the wrapped writer.
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written before delegating to the wrapped writer.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap returns the wrapped writer so http.ResponseController can reach optional interfaces.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLog returns a middleware that writes one structured access log entry per request
+// through the given zap logger once the request completes. The entry includes the
+// trace and span IDs of the span found in the request context, so the middleware
+// should be placed inside the tracing middleware (e.g. otelhttp.NewHandler) that
+// starts the server span.
+//
+// Example usage:
+//
+//	handler := otelhttp.NewHandler(http.AccessLog(logger)(mux), "http-server")
+//
+// Parameters:
+//   - logger: The zap logger used to write the access log entries
+//   - fields: The request attributes to log; DefaultAccessLogFields is used when empty
+//
+// Returns:
+//   - func(http.Handler) http.Handler: A middleware wrapping the next handler
+func AccessLog(logger *zap.Logger, fields ...AccessLogField) func(http.Handler) http.Handler {
+	if len(fields) == 0 {
+		fields = DefaultAccessLogFields
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			logFields := make([]zapcore.Field, 0, len(fields)+1)
+			for _, f := range fields {
+				logFields = append(logFields, accessLogField(f, r, rec, time.Since(start)))
+			}
+			logFields = append(logFields, tracingzap.Format(r.Context()))
+
+			logger.Info("http access", logFields...)
+		})
+	}
+}
+
+// accessLogField converts an AccessLogField into the corresponding zap field.
+func accessLogField(f AccessLogField, r *http.Request, rec *responseRecorder, elapsed time.Duration) zapcore.Field {
+	switch f {
+	case FieldMethod:
+		return zap.String(string(f), r.Method)
+	case FieldPath:
+		return zap.String(string(f), r.URL.Path)
+	case FieldStatus:
+		return zap.Int(string(f), rec.status)
+	case FieldDuration:
+		return zap.Duration(string(f), elapsed)
+	case FieldRemoteAddr:
+		return zap.String(string(f), r.RemoteAddr)
+	case FieldUserAgent:
+		return zap.String(string(f), r.UserAgent())
+	case FieldBytes:
+		return zap.Int(string(f), rec.bytes)
+	default:
+		return zap.Skip()
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// remoteSpanContext is the span context carried by the requests of the tests.
+var remoteSpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	TraceFlags: trace.FlagsSampled,
+})
+
+func TestAccessLogWritesDefaultFieldsAndTraceContext(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	handler := AccessLog(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+	if logs.Len() != 1 {
+		t.Fatalf("got %d log entries, want 1", logs.Len())
+	}
+
+	fields := logs.All()[0].ContextMap()
+	want := map[string]any{
+		"method":   http.MethodPost,
+		"path":     "/orders",
+		"status":   int64(http.StatusCreated),
+		"trace_id": span.SpanContext().TraceID().String(),
+		"span_id":  span.SpanContext().SpanID().String(),
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("field %s = %v, want %v", key, fields[key], value)
+		}
+	}
+
+	if _, ok := fields["duration"]; !ok {
+		t.Error("duration field missing")
+	}
+}
+
+func TestAccessLogWritesSelectedFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	handler := AccessLog(zap.New(core), FieldBytes, FieldUserAgent)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "probe/1.0")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	fields := logs.All()[0].ContextMap()
+	if fields["bytes"] != int64(5) {
+		t.Errorf("bytes = %v, want 5", fields["bytes"])
+	}
+
+	if fields["user_agent"] != "probe/1.0" {
+		t.Errorf("user_agent = %v, want probe/1.0", fields["user_agent"])
+	}
+
+	for _, key := range []string{"method", "path", "trace_id"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("unexpected field %s", key)
+		}
+	}
+}
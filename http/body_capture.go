@@ -0,0 +1,202 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultBodyCaptureMaxSize is the default number of body bytes recorded per payload
+	DefaultBodyCaptureMaxSize = 1024
+
+	// redactedBodyValue replaces the value of redacted body fields
+	redactedBodyValue = "[REDACTED]"
+)
+
+// BodyCaptureConfig defines the capture of request and response bodies as span attributes.
+type BodyCaptureConfig struct {
+	// Enabled indicates whether the bodies are recorded; capture is off by default
+	Enabled bool
+
+	// MaxSize is the number of bytes recorded per body, DefaultBodyCaptureMaxSize when zero
+	MaxSize int
+
+	// RedactedFields lists the JSON keys and form fields whose values are masked
+	RedactedFields []string
+}
+
+// bodyCapture holds the writes or reads of a body up to a maximum size.
+type bodyCapture struct {
+	// buf holds the captured bytes
+	buf bytes.Buffer
+
+	// maxSize is the number of bytes captured
+	maxSize int
+
+	// truncated indicates that the body exceeded maxSize
+	truncated bool
+}
+
+// capture appends the bytes to the capture, up to the maximum size.
+func (c *bodyCapture) capture(b []byte) {
+	if room := c.maxSize - c.buf.Len(); len(b) > room {
+		b = b[:max(room, 0)]
+		c.truncated = true
+	}
+
+	c.buf.Write(b)
+}
+
+// capturingBody wraps a request body to capture the bytes read by the handler.
+type capturingBody struct {
+	io.ReadCloser
+
+	// capture holds the bytes read
+	capture *bodyCapture
+}
+
+// Read captures the bytes read from the wrapped body.
+func (b *capturingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.capture(p[:n])
+	return n, err
+}
+
+// capturingWriter wraps an http.ResponseWriter to capture the bytes written by the handler.
+type capturingWriter struct {
+	http.ResponseWriter
+
+	// capture holds the bytes written
+	capture *bodyCapture
+}
+
+// Write captures the bytes before delegating to the wrapped writer.
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.capture.capture(b[:n])
+	return n, err
+}
+
+// Unwrap returns the wrapped writer so http.ResponseController can reach optional interfaces.
+func (w *capturingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// NewHandler wraps a handler with otelhttp.NewHandler, starting a server span per request,
+// and optionally records the request and response bodies on the span for debugging. When
+// capture is enabled, the bytes read and written by the handler are recorded, up to MaxSize
+// bytes each, as the http.request.body and http.response.body attributes; the values of the
+// redacted JSON keys and form fields are masked, and the http.request.body.truncated and
+// http.response.body.truncated attributes flag the bodies exceeding MaxSize.
+//
+// Example usage:
+//
+//	handler := http.NewHandler(mux, "http-server", http.BodyCaptureConfig{
+//		Enabled:        os.Getenv("TRACE_BODIES") == "true",
+//		MaxSize:        2048,
+//		RedactedFields: []string{"password", "token"},
+//	})
+//
+// Parameters:
+//   - handler: The handler to trace
+//   - operation: The operation name given to otelhttp
+//   - capture: The body capture settings
+//   - opts: Additional otelhttp options
+//
+// Returns:
+//   - http.Handler: The traced handler
+func NewHandler(handler http.Handler, operation string, capture BodyCaptureConfig, opts ...otelhttp.Option) http.Handler {
+	if capture.Enabled {
+		handler = captureBodies(handler, capture)
+	}
+
+	return otelhttp.NewHandler(handler, operation, opts...)
+}
+
+// captureBodies returns a middleware recording the request and response bodies on the span
+// found in the request context.
+func captureBodies(next http.Handler, cfg BodyCaptureConfig) http.Handler {
+	maxSize := cfg.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultBodyCaptureMaxSize
+	}
+
+	redactors := newBodyRedactors(cfg.RedactedFields)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCapture := &bodyCapture{maxSize: maxSize}
+		resCapture := &bodyCapture{maxSize: maxSize}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &capturingBody{ReadCloser: r.Body, capture: reqCapture}
+		}
+
+		next.ServeHTTP(&capturingWriter{ResponseWriter: w, capture: resCapture}, r)
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(bodyAttributes("http.request.body", reqCapture, redactors)...)
+		span.SetAttributes(bodyAttributes("http.response.body", resCapture, redactors)...)
+	})
+}
+
+// bodyAttributes builds the attributes of a captured body, masking the redacted fields.
+func bodyAttributes(key string, capture *bodyCapture, redactors []bodyRedactor) []attribute.KeyValue {
+	if capture.buf.Len() == 0 {
+		return nil
+	}
+
+	body := capture.buf.Bytes()
+	for _, r := range redactors {
+		body = r.pattern.ReplaceAll(body, r.replacement)
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(key, string(body))}
+	if capture.truncated {
+		attrs = append(attrs, attribute.Bool(key+".truncated", true))
+	}
+
+	return attrs
+}
+
+// bodyRedactor masks the value of a field in a captured body.
+type bodyRedactor struct {
+	// pattern matches the field and its value
+	pattern *regexp.Regexp
+
+	// replacement replaces the match, keeping the field name
+	replacement []byte
+}
+
+// newBodyRedactors builds the redactors of the JSON keys and form fields. JSON string values
+// cut by the truncation are matched up to the end of the body, so partial secrets are masked too.
+func newBodyRedactors(fields []string) []bodyRedactor {
+	redactors := make([]bodyRedactor, 0, 2*len(fields))
+
+	for _, field := range fields {
+		quoted := regexp.QuoteMeta(field)
+
+		redactors = append(redactors,
+			bodyRedactor{
+				pattern:     regexp.MustCompile(fmt.Sprintf(`("%s"\s*:\s*)(?:"(?:[^"\\]|\\.)*(?:"|$)|[^,}\]\s]+)`, quoted)),
+				replacement: []byte(fmt.Sprintf(`${1}"%s"`, redactedBodyValue)),
+			},
+			bodyRedactor{
+				pattern:     regexp.MustCompile(fmt.Sprintf(`(^|&)(%s=)[^&]*`, quoted)),
+				replacement: []byte(fmt.Sprintf(`${1}${2}%s`, redactedBodyValue)),
+			},
+		)
+	}
+
+	return redactors
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// serveCapturing serves a request with the body through an echoing handler wrapped by
+// NewHandler, and returns the attributes of the server span.
+func serveCapturing(t *testing.T, capture BodyCaptureConfig, body string) map[attribute.Key]attribute.Value {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(data)
+	})
+
+	handler := NewHandler(echo, "orders", capture, otelhttp.WithTracerProvider(tp))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body)))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want the server span", len(spans))
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestNewHandlerCapturesBodiesWhenEnabled(t *testing.T) {
+	attrs := serveCapturing(t, BodyCaptureConfig{Enabled: true, RedactedFields: []string{"password"}}, `{"user":"jane","password":"s3cret"}`)
+
+	want := `{"user":"jane","password":"[REDACTED]"}`
+	for _, key := range []attribute.Key{"http.request.body", "http.response.body"} {
+		if got := attrs[key].AsString(); got != want {
+			t.Errorf("%s = %s, want %s", key, got, want)
+		}
+	}
+
+	if _, ok := attrs["http.request.body.truncated"]; ok {
+		t.Error("a body within the limit was flagged as truncated")
+	}
+}
+
+func TestNewHandlerTruncatesBodiesAtTheLimit(t *testing.T) {
+	attrs := serveCapturing(t, BodyCaptureConfig{Enabled: true, MaxSize: 8}, "user=jane&password=s3cret")
+
+	if got := attrs["http.request.body"].AsString(); got != "user=jan" {
+		t.Errorf("http.request.body = %q, want the first 8 bytes", got)
+	}
+
+	if !attrs["http.request.body.truncated"].AsBool() || !attrs["http.response.body.truncated"].AsBool() {
+		t.Error("the truncated bodies were not flagged")
+	}
+}
+
+func TestNewHandlerRedactsFormFields(t *testing.T) {
+	attrs := serveCapturing(t, BodyCaptureConfig{Enabled: true, RedactedFields: []string{"password"}}, "user=jane&password=s3cret")
+
+	if got := attrs["http.request.body"].AsString(); got != "user=jane&password=[REDACTED]" {
+		t.Errorf("http.request.body = %q, want the password redacted", got)
+	}
+}
+
+func TestNewHandlerDoesNotCaptureBodiesByDefault(t *testing.T) {
+	attrs := serveCapturing(t, BodyCaptureConfig{}, `{"user":"jane"}`)
+
+	for _, key := range []attribute.Key{"http.request.body", "http.response.body"} {
+		if _, ok := attrs[key]; ok {
+			t.Errorf("%s captured although body capture is disabled", key)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/goxkit/tracing/sampler"
+)
+
+const (
+	// DebugTraceHeader is the default header asking for a request to be traced
+	DebugTraceHeader = "X-Debug-Trace"
+)
+
+// DebugTrace returns a middleware forcing the sampling of the requests carrying the header
+// with a true value (e.g. "X-Debug-Trace: true"), through sampler.ForceSampling. It only has
+// an effect with the sampler created by sampler.NewDebug, e.g. with options.WithDebugSampling,
+// and must wrap the tracing middleware so the server span is started from the marked context.
+//
+// Example usage:
+//
+//	handler := tracinghttp.DebugTrace(tracinghttp.DebugTraceHeader)(otelhttp.NewHandler(mux, "http-server"))
+//
+// Parameters:
+//   - header: The header asking for the request to be traced
+//
+// Returns:
+//   - func(http.Handler) http.Handler: The debug trace middleware
+func DebugTrace(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if debug, _ := strconv.ParseBool(r.Header.Get(header)); debug {
+				r = r.WithContext(sampler.ForceSampling(r.Context()))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goxkit/tracing/sampler"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestDebugTraceForcesSamplingOfFlaggedRequests(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler.NewDebug(sdktrace.NeverSample(), "")))
+
+	var sampled bool
+	handler := DebugTrace(DebugTraceHeader)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, span := tp.Tracer("test").Start(r.Context(), "GET /orders")
+		defer span.End()
+
+		sampled = span.SpanContext().IsSampled()
+	}))
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "true", want: true},
+		{header: "1", want: true},
+		{header: "false", want: false},
+		{header: "", want: false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if tt.header != "" {
+			req.Header.Set(DebugTraceHeader, tt.header)
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if sampled != tt.want {
+			t.Errorf("%s: %q sampled = %t, want %t", DebugTraceHeader, tt.header, sampled, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+var (
+	// DefaultExcludedPaths are the health and metrics endpoints left untraced by ExcludePaths
+	// when no path prefix is given
+	DefaultExcludedPaths = []string{"/healthz", "/livez", "/readyz", "/metrics"}
+)
+
+// ExcludePaths returns an otelhttp filter skipping the requests whose path is one of the
+// given prefixes or lies below it, such as health and metrics endpoints polled by
+// orchestrators and scrapers, whose spans add volume without value. A prefix matches whole
+// path segments: /healthz excludes /healthz and /healthz/db, not /healthzone. Without
+// prefixes, DefaultExcludedPaths are excluded.
+//
+// Example usage:
+//
+//	handler := otelhttp.NewHandler(mux, "http-server",
+//		otelhttp.WithFilter(http.ExcludePaths("/healthz", "/metrics")),
+//	)
+//
+// Parameters:
+//   - prefixes: The path prefixes left untraced
+//
+// Returns:
+//   - otelhttp.Filter: The filter tracing the requests to other paths
+func ExcludePaths(prefixes ...string) otelhttp.Filter {
+	if len(prefixes) == 0 {
+		prefixes = DefaultExcludedPaths
+	}
+
+	return func(r *http.Request) bool {
+		for _, prefix := range prefixes {
+			if matchesPathPrefix(r.URL.Path, prefix) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// WithoutPaths is an otelhttp option installing the ExcludePaths filter, for use with
+// otelhttp.NewHandler or NewHandler.
+//
+// Example usage:
+//
+//	handler := http.NewHandler(mux, "http-server", http.BodyCaptureConfig{}, http.WithoutPaths())
+//
+// Parameters:
+//   - prefixes: The path prefixes left untraced, DefaultExcludedPaths when none is given
+//
+// Returns:
+//   - otelhttp.Option: The filter option
+func WithoutPaths(prefixes ...string) otelhttp.Option {
+	return otelhttp.WithFilter(ExcludePaths(prefixes...))
+}
+
+// matchesPathPrefix reports whether the path is the prefix or lies below it.
+//
+// Parameters:
+//   - path: The request path
+//   - prefix: The path prefix
+//
+// Returns:
+//   - bool: Whether the path matches the prefix
+func matchesPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithoutPathsSkipsExcludedRequests(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	handler := otelhttp.NewHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), "http-server",
+		otelhttp.WithTracerProvider(tp),
+		WithoutPaths(),
+	)
+
+	for _, path := range []string{"/healthz", "/readyz", "/metrics", "/orders"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if ended := recorder.Ended(); len(ended) != 1 {
+		t.Errorf("got %d spans, want one for /orders only", len(ended))
+	}
+}
+
+func TestExcludePaths(t *testing.T) {
+	tests := []struct {
+		prefixes []string
+		path     string
+		traced   bool
+	}{
+		{path: "/healthz", traced: false},
+		{path: "/healthz/db", traced: false},
+		{path: "/healthzone", traced: true},
+		{path: "/livez", traced: false},
+		{path: "/orders", traced: true},
+		{prefixes: []string{"/internal/"}, path: "/internal/debug", traced: false},
+		{prefixes: []string{"/internal/"}, path: "/internal", traced: false},
+		{prefixes: []string{"/internal"}, path: "/healthz", traced: true},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+		if got := ExcludePaths(tt.prefixes...)(req); got != tt.traced {
+			t.Errorf("ExcludePaths(%v) on %s = %t, want %t", tt.prefixes, tt.path, got, tt.traced)
+		}
+	}
+}
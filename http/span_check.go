@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// spanCheckTransport warns about outbound requests made without an active span.
+type spanCheckTransport struct {
+	// base performs the requests
+	base http.RoundTripper
+
+	// logger writes the warnings
+	logger *zap.Logger
+
+	// reported holds the method and host pairs already reported
+	reported sync.Map
+}
+
+// NewSpanCheckTransport wraps an http.RoundTripper so a warning is logged when an outbound
+// request is made with a context holding no span, e.g. a request built with http.NewRequest
+// instead of http.NewRequestWithContext, or sent outside any traced operation. Such a request
+// cannot propagate the trace to the downstream service. The warning is logged once per method
+// and host. It is a development aid catching missing instrumentation and adds no tracing itself.
+//
+// Example usage:
+//
+//	client := &http.Client{Transport: tracinghttp.NewSpanCheckTransport(tracing.NewHTTPTransport(nil), cfgs.Logger)}
+//
+// Parameters:
+//   - base: The round tripper performing the requests; http.DefaultTransport is used when nil
+//   - logger: The logger writing the warnings
+//
+// Returns:
+//   - http.RoundTripper: The checking round tripper
+func NewSpanCheckTransport(base http.RoundTripper, logger *zap.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &spanCheckTransport{base: base, logger: logger}
+}
+
+// CheckSpans installs the span check of NewSpanCheckTransport on the transport of the client,
+// such as http.DefaultClient, to find the call sites sending requests without a span.
+//
+// Example usage:
+//
+//	tracinghttp.CheckSpans(http.DefaultClient, cfgs.Logger)
+//
+// Parameters:
+//   - client: The client whose transport is wrapped
+//   - logger: The logger writing the warnings
+func CheckSpans(client *http.Client, logger *zap.Logger) {
+	client.Transport = NewSpanCheckTransport(client.Transport, logger)
+}
+
+// RoundTrip warns when the request context holds no span, then performs the request.
+func (t *spanCheckTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(r.Context()).IsValid() {
+		key := r.Method + " " + r.URL.Host
+		if _, loaded := t.reported.LoadOrStore(key, struct{}{}); !loaded {
+			t.logger.Warn("outbound request without an active span, the trace context will not be propagated",
+				zap.String("method", r.Method),
+				zap.String("host", r.URL.Host),
+			)
+		}
+	}
+
+	return t.base.RoundTrip(r)
+}
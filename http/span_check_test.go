@@ -0,0 +1,67 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// get sends a GET request with the given context through the client.
+func get(t *testing.T, client *http.Client, ctx context.Context, url string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestCheckSpansWarnsOncePerHostAboutRequestsWithoutSpan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	client := &http.Client{}
+	CheckSpans(client, zap.New(core))
+
+	get(t, client, context.Background(), server.URL+"/orders")
+	get(t, client, context.Background(), server.URL+"/payments")
+
+	if logs.Len() != 1 {
+		t.Fatalf("got %d warnings, want 1", logs.Len())
+	}
+
+	if fields := logs.All()[0].ContextMap(); fields["method"] != http.MethodGet || fields["host"] != server.Listener.Addr().String() {
+		t.Errorf("fields = %v, want the method and host of the request", fields)
+	}
+}
+
+func TestSpanCheckTransportIsSilentWithASpan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	client := &http.Client{Transport: NewSpanCheckTransport(nil, zap.New(core))}
+
+	get(t, client, trace.ContextWithSpanContext(context.Background(), remoteSpanContext), server.URL+"/orders")
+
+	if logs.Len() != 0 {
+		t.Errorf("got %d warnings for a request with a span, want none", logs.Len())
+	}
+}
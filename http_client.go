@@ -0,0 +1,79 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+// installedTracerProvider is a tracer provider resolving the installed tracer provider each
+// time a span starts, so instrumentations created before Install, or caching their tracer,
+// still use the provider Install built.
+type installedTracerProvider struct {
+	embedded.TracerProvider
+}
+
+// Tracer returns a tracer resolving the installed tracer provider when a span starts.
+func (installedTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return installedTracer{name: name, opts: opts}
+}
+
+// installedTracer starts spans with the tracer of the installed tracer provider.
+type installedTracer struct {
+	embedded.Tracer
+
+	// name is the instrumentation scope name of the tracer
+	name string
+
+	// opts are the options of the tracer
+	opts []trace.TracerOption
+}
+
+// Start starts a span with the tracer of the installed tracer provider.
+func (t installedTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer(t.name, t.opts...).Start(ctx, spanName, opts...)
+}
+
+// NewHTTPTransport wraps an http.RoundTripper so every outbound request creates a client span
+// and carries the trace context to the downstream service (e.g. the traceparent header).
+// It uses the tracer provider installed by Install, even when it is not registered globally,
+// and the global propagator.
+//
+// Example usage:
+//
+//	client := &http.Client{Transport: tracing.NewHTTPTransport(nil), Timeout: 5 * time.Second}
+//
+// Parameters:
+//   - base: The round tripper performing the requests; http.DefaultTransport is used when nil
+//
+// Returns:
+//   - http.RoundTripper: The instrumented round tripper
+func NewHTTPTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return otelhttp.NewTransport(
+		base,
+		otelhttp.WithTracerProvider(installedTracerProvider{}),
+		otelhttp.WithPropagators(otel.GetTextMapPropagator()),
+	)
+}
+
+// NewHTTPClient creates an http.Client whose requests are traced and propagate the trace
+// context. Requests must be built with the caller's context (e.g. http.NewRequestWithContext)
+// for the client span to join the active trace.
+//
+// Returns:
+//   - *http.Client: The instrumented HTTP client
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: NewHTTPTransport(nil)}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useTraceContext sets the W3C trace context as the global propagator until the test ends.
+func useTraceContext(t *testing.T) {
+	t.Helper()
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+}
+
+func TestHTTPClientPropagatesTraceContext(t *testing.T) {
+	useTraceContext(t)
+	recorder := installTestProvider(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := Tracer("").Start(context.Background(), "orders.create")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := NewHTTPClient().Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	client := spans[0]
+	if client.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %s, want client", client.SpanKind())
+	}
+
+	if client.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("client span is not a child of the caller span")
+	}
+
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	received := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	if received.SpanID() != client.SpanContext().SpanID() || received.TraceID() != client.SpanContext().TraceID() {
+		t.Errorf("traceparent = %q, want the context of the client span", traceparent)
+	}
+}
+
+func TestHTTPTransportUsesProviderInstalledLater(t *testing.T) {
+	useTraceContext(t)
+
+	transport := NewHTTPTransport(nil)
+	recorder := installTestProvider(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := len(recorder.Ended()); got != 1 {
+		t.Errorf("got %d spans, want the client span recorded by the provider installed after the transport", got)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ExtractHTTP returns the context of the request carrying the trace context and baggage
+// extracted from its headers with the global propagator. It continues an inbound trace in
+// handlers that cannot use the otelhttp middleware, such as those of custom routers.
+//
+// Example usage:
+//
+//	ctx, span := tracing.Tracer("orders").Start(tracing.ExtractHTTP(r), "orders.create",
+//		trace.WithSpanKind(trace.SpanKindServer))
+//	defer span.End()
+//
+// Parameters:
+//   - r: The inbound request
+//
+// Returns:
+//   - context.Context: The request context with the extracted trace context
+func ExtractHTTP(r *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// InjectHTTP writes the trace context and baggage of ctx into the headers of an outbound
+// request with the global propagator, so the called service continues the trace without
+// the otelhttp transport.
+//
+// Example usage:
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	tracing.InjectHTTP(ctx, req)
+//
+// Parameters:
+//   - ctx: The context holding the trace context to propagate
+//   - r: The outbound request
+func InjectHTTP(ctx context.Context, r *http.Request) {
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+}
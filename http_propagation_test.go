@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceparent is the traceparent header of upstreamSpanContext.
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestExtractHTTP(t *testing.T) {
+	useTraceContext(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("traceparent", traceparent)
+
+	sc := trace.SpanContextFromContext(ExtractHTTP(req))
+	if !sc.IsValid() || !sc.Equal(upstreamSpanContext) {
+		t.Errorf("extracted span context = %v, want %v", sc, upstreamSpanContext)
+	}
+}
+
+func TestExtractHTTPWithoutTraceparent(t *testing.T) {
+	useTraceContext(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+
+	if sc := trace.SpanContextFromContext(ExtractHTTP(req)); sc.IsValid() {
+		t.Errorf("extracted span context = %v, want none", sc)
+	}
+}
+
+func TestInjectHTTP(t *testing.T) {
+	useTraceContext(t)
+
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), upstreamSpanContext)
+	req := &http.Request{Method: http.MethodGet}
+
+	InjectHTTP(ctx, req)
+
+	if got := req.Header.Get("traceparent"); got != traceparent {
+		t.Errorf("traceparent = %q, want %q", got, traceparent)
+	}
+}
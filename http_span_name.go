@@ -0,0 +1,65 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	// PathIDPlaceholder replaces the identifier segments of normalized URL paths
+	PathIDPlaceholder = "{id}"
+)
+
+var (
+	// uuidSegment matches a path segment holding a UUID
+	uuidSegment = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// numericSegment matches a path segment holding a number
+	numericSegment = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// HTTPSpanNameFormatter names HTTP spans after the request path with its identifier
+// segments (UUIDs and numbers) replaced by PathIDPlaceholder, so raw paths such as
+// /orders/123e4567-e89b-12d3-a456-426614174000 produce the low-cardinality span name
+// /orders/{id}. It is intended for handlers instrumented with otelhttp that are not
+// served by a router exposing the route template.
+//
+// Example usage:
+//
+//	handler := otelhttp.NewHandler(mux, "server",
+//		otelhttp.WithSpanNameFormatter(tracing.HTTPSpanNameFormatter),
+//	)
+//
+// Parameters:
+//   - _: The operation name given to otelhttp, ignored
+//   - r: The traced request
+//
+// Returns:
+//   - string: The normalized span name
+func HTTPSpanNameFormatter(_ string, r *http.Request) string {
+	return normalizePath(r.URL.Path)
+}
+
+// normalizePath replaces the identifier segments of a URL path with PathIDPlaceholder.
+//
+// Parameters:
+//   - path: The URL path to normalize
+//
+// Returns:
+//   - string: The normalized path
+func normalizePath(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		if uuidSegment.MatchString(segment) || numericSegment.MatchString(segment) {
+			segments[i] = PathIDPlaceholder
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
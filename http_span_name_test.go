@@ -0,0 +1,34 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSpanNameFormatter(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "/orders/123e4567-e89b-12d3-a456-426614174000", want: "/orders/{id}"},
+		{target: "/orders/123E4567-E89B-12D3-A456-426614174000/items/42", want: "/orders/{id}/items/{id}"},
+		{target: "/users/42?expand=orders", want: "/users/{id}"},
+		{target: "/users/me", want: "/users/me"},
+		{target: "/v2/orders", want: "/v2/orders"},
+		{target: "/orders/", want: "/orders/"},
+		{target: "/", want: "/"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		if got := HTTPSpanNameFormatter("server", req); got != tt.want {
+			t.Errorf("HTTPSpanNameFormatter(%s) = %s, want %s", tt.target, got, tt.want)
+		}
+	}
+}
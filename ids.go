@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceID returns the hexadecimal trace ID of the span context found in the context,
+// e.g. to return it in an X-Trace-Id response header for support correlation. Remote
+// span contexts extracted from an inbound request are used as well.
+//
+// Example usage:
+//
+//	w.Header().Set("X-Trace-Id", tracing.TraceID(r.Context()))
+//
+// Parameters:
+//   - ctx: The context containing the trace information
+//
+// Returns:
+//   - string: The trace ID, or an empty string when the context holds no valid span context
+func TraceID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return ""
+	}
+
+	return spanCtx.TraceID().String()
+}
+
+// SpanID returns the hexadecimal span ID of the span context found in the context.
+//
+// Parameters:
+//   - ctx: The context containing the trace information
+//
+// Returns:
+//   - string: The span ID, or an empty string when the context holds no valid span context
+func SpanID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return ""
+	}
+
+	return spanCtx.SpanID().String()
+}
+
+// SpanFromIDs returns a copy of the context holding the remote span context identified by
+// the given hexadecimal IDs, such as IDs received out-of-band from an external system. Spans
+// started from the returned context are children of that span and share its trace ID; to
+// link to it instead, use trace.LinkFromContext on the returned context.
+//
+// Example usage:
+//
+//	ctx, err := tracing.SpanFromIDs(ctx, job.TraceID, job.SpanID, true)
+//	if err != nil {
+//		return err
+//	}
+//	ctx, span := tracing.Tracer("jobs").Start(ctx, "jobs.run")
+//
+// Parameters:
+//   - ctx: The parent context
+//   - traceID: The 32-character hexadecimal trace ID
+//   - spanID: The 16-character hexadecimal span ID
+//   - sampled: Whether the remote span was sampled
+//
+// Returns:
+//   - context.Context: Context containing the remote span context
+//   - error: An error describing an invalid trace or span ID
+func SpanFromIDs(ctx context.Context, traceID, spanID string, sampled bool) (context.Context, error) {
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid trace ID %q: %w", traceID, err)
+	}
+
+	sid, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid span ID %q: %w", spanID, err)
+	}
+
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanCtx), nil
+}
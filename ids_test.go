@@ -0,0 +1,96 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// upstreamSpanContext is the span context received from an upstream service in the tests.
+var upstreamSpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	TraceFlags: trace.FlagsSampled,
+	Remote:     true,
+})
+
+func TestTraceIDAndSpanIDOfLocalSpans(t *testing.T) {
+	installTestProvider(t)
+
+	ctx, span := Tracer("").Start(context.Background(), "orders.create")
+	defer span.End()
+
+	if got, want := TraceID(ctx), span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("TraceID = %q, want %q", got, want)
+	}
+
+	if got, want := SpanID(ctx), span.SpanContext().SpanID().String(); got != want {
+		t.Errorf("SpanID = %q, want %q", got, want)
+	}
+}
+
+func TestTraceIDAndSpanIDOfRemoteSpanContexts(t *testing.T) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), upstreamSpanContext)
+
+	if got := TraceID(ctx); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceID = %q, want the upstream trace ID", got)
+	}
+
+	if got := SpanID(ctx); got != "00f067aa0ba902b7" {
+		t.Errorf("SpanID = %q, want the upstream span ID", got)
+	}
+}
+
+func TestTraceIDAndSpanIDWithoutSpan(t *testing.T) {
+	if TraceID(context.Background()) != "" || SpanID(context.Background()) != "" {
+		t.Error("IDs returned for a context without span")
+	}
+}
+
+func TestSpanFromIDsParentsNewSpans(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, err := SpanFromIDs(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	if err != nil {
+		t.Fatalf("SpanFromIDs: %v", err)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); !sc.Equal(upstreamSpanContext) {
+		t.Errorf("span context = %v, want %v", sc, upstreamSpanContext)
+	}
+
+	_, span := Tracer("").Start(ctx, "jobs.run")
+	span.End()
+
+	ended := recorder.Ended()[0]
+	if ended.SpanContext().TraceID() != upstreamSpanContext.TraceID() || ended.Parent().SpanID() != upstreamSpanContext.SpanID() {
+		t.Error("the span is not a child of the span identified by the IDs")
+	}
+}
+
+func TestSpanFromIDsRejectsInvalidIDs(t *testing.T) {
+	tests := []struct {
+		traceID string
+		spanID  string
+	}{
+		{traceID: "not-a-trace-id", spanID: "00f067aa0ba902b7"},
+		{traceID: "00000000000000000000000000000000", spanID: "00f067aa0ba902b7"},
+		{traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa"},
+	}
+
+	for _, tt := range tests {
+		ctx, err := SpanFromIDs(context.Background(), tt.traceID, tt.spanID, false)
+		if err == nil {
+			t.Errorf("SpanFromIDs(%s, %s) returned no error", tt.traceID, tt.spanID)
+		}
+
+		if trace.SpanContextFromContext(ctx).IsValid() {
+			t.Errorf("SpanFromIDs(%s, %s) set a span context despite the error", tt.traceID, tt.spanID)
+		}
+	}
+}
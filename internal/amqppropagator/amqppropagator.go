@@ -0,0 +1,39 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package amqppropagator holds the propagator configured by the installers for AMQP messages.
+// It lets the internal provider configure the amqp package without importing it.
+package amqppropagator
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var (
+	// installed holds the propagator configured by the last installation
+	installed atomic.Pointer[propagation.TextMapPropagator]
+)
+
+// Set configures the propagator used for AMQP messages by the last installation, replacing
+// the one of a previous installation. Passing nil restores the default of the amqp package.
+//
+// Parameters:
+//   - p: The propagator to use for AMQP headers, nil for the default
+func Set(p propagation.TextMapPropagator) {
+	installed.Store(&p)
+}
+
+// Get returns the propagator configured with Set.
+//
+// Returns:
+//   - propagation.TextMapPropagator: The configured propagator, nil when none is configured
+func Get() propagation.TextMapPropagator {
+	if p := installed.Load(); p != nil {
+		return *p
+	}
+
+	return nil
+}
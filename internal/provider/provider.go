@@ -0,0 +1,361 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package provider builds the tracer provider shared by the exporting installers.
+// Every installer creates its own span exporter and relies on this package to apply
+// the provider-level options (sampling, span limits, processors and resource) the same
+// way, whatever the destination of the spans.
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/exporter"
+	"github.com/goxkit/tracing/internal/amqppropagator"
+	"github.com/goxkit/tracing/options"
+	"github.com/goxkit/tracing/processor"
+	"github.com/goxkit/tracing/propagation"
+	"github.com/goxkit/tracing/sampler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.uber.org/zap"
+)
+
+const (
+	// InstanceIDEnvKey is the environment variable providing the service instance ID
+	InstanceIDEnvKey = "SERVICE_INSTANCE_ID"
+)
+
+var (
+	// instanceID identifies the service instance, stable for the lifetime of the process
+	instanceID = newInstanceID()
+)
+
+// New creates a tracer provider exporting spans through the given exporter with a batch
+// span processor and applies the provider-level options. The provider is not registered,
+// see Register.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - o: The resolved installation options
+//   - exp: The span exporter receiving the ended spans
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The configured tracer provider
+//   - error: Any error encountered during setup
+func New(cfgs *configs.Configs, o *options.Config, exp sdktrace.SpanExporter) (*sdktrace.TracerProvider, error) {
+	if o.CircuitBreaker.FailureThreshold > 0 {
+		breaker, err := exporter.NewCircuitBreaker(exp, o.CircuitBreaker.FailureThreshold, o.CircuitBreaker.Cooldown, o.ExportMetricsProvider)
+		if err != nil {
+			cfgs.Logger.Error("failed to create export circuit breaker", zap.Error(err))
+			return nil, err
+		}
+		exp = breaker
+	}
+
+	spanProcessor, err := newBatchProcessor(o, exp)
+	if err != nil {
+		cfgs.Logger.Error("failed to create export metrics processor", zap.Error(err))
+		return nil, err
+	}
+
+	var keepRules []processor.KeepRule
+	if o.LatencyKeepThreshold > 0 {
+		keepRules = append(keepRules, processor.KeepSlow(o.LatencyKeepThreshold))
+	}
+
+	if o.KeepErrorSpans {
+		keepRules = append(keepRules, processor.KeepErrors())
+	}
+
+	if len(keepRules) > 0 {
+		spanProcessor = processor.NewKeep(spanProcessor, keepRules...)
+	}
+
+	if o.MinSpanDuration > 0 {
+		spanProcessor = processor.NewMinDuration(spanProcessor, o.MinSpanDuration)
+	}
+
+	if o.MaxAttributeLength > 0 {
+		spanProcessor = processor.NewTruncating(spanProcessor, o.MaxAttributeLength)
+	}
+
+	if len(o.RedactedAttributes) > 0 {
+		spanProcessor = processor.NewRedacting(spanProcessor, o.RedactedAttributes...)
+	}
+
+	if o.AllowedAttributes != nil {
+		spanProcessor = processor.NewAllowlist(spanProcessor, o.AllowedAttributes...)
+	}
+
+	spanProcessor = processor.Chain(spanProcessor, o.Processors...)
+
+	spanSampler := baseSampler(cfgs, o)
+	var closers []func()
+
+	if o.JaegerRemote.ServerURL != "" {
+		serviceName := o.JaegerRemote.ServiceName
+		if serviceName == "" {
+			serviceName = cfgs.AppConfigs.Name
+		}
+
+		remote := sampler.NewJaegerRemote(o.JaegerRemote.ServerURL, serviceName, spanSampler, o.JaegerRemote.RefreshInterval)
+		spanSampler = remote
+		closers = append(closers, remote.Close)
+	}
+
+	// Only the spans dropped by the ratio sampler are recorded for the keep rules, spans
+	// dropped by sampling rules (e.g. health checks) stay dropped.
+	if len(keepRules) > 0 {
+		spanSampler = sampler.NewRecordOnly(spanSampler)
+	}
+
+	if len(o.SamplingRules) > 0 {
+		spanSampler = sampler.NewRuleBased(spanSampler, o.SamplingRules...)
+	}
+
+	if o.DebugSampling {
+		spanSampler = sampler.NewDebug(spanSampler, o.DebugBaggageKey)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(spanSampler),
+		sdktrace.WithRawSpanLimits(o.SpanLimits),
+		sdktrace.WithResource(Resource(cfgs, o.SchemaURL, o.ResourceAttributes...)),
+	}
+
+	if o.IDGenerator != nil {
+		providerOpts = append(providerOpts, sdktrace.WithIDGenerator(o.IDGenerator))
+	}
+
+	if len(o.EnvAttributes) > 0 {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewEnvAttributes(o.EnvAttributes)))
+	}
+
+	if len(o.BaggageAttributes) > 0 {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewBaggageAttributes(o.BaggageAttributes...)))
+	}
+
+	if o.TenantContextKey != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewTenantAttribute(o.TenantContextKey)))
+	}
+
+	if o.HostAttributes != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewHost(*o.HostAttributes)))
+	}
+
+	if o.EventLogs {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewEventLogs(cfgs.Logger)))
+	}
+
+	if o.NameCardinalityWarnings {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewNameCardinality(cfgs.Logger)))
+	}
+
+	if o.MaxSpanLifetime > 0 {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(processor.NewLeakWatchdog(o.MaxSpanLifetime)))
+	}
+
+	if o.SpanMetricsProvider != nil {
+		metricsProcessor, err := processor.NewSpanMetrics(o.SpanMetricsProvider)
+		if err != nil {
+			cfgs.Logger.Error("failed to create span metrics processor", zap.Error(err))
+			return nil, err
+		}
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(metricsProcessor))
+	}
+
+	if o.AttributeCountMetricsProvider != nil {
+		countProcessor, err := processor.NewAttributeCount(o.AttributeCountMetricsProvider)
+		if err != nil {
+			cfgs.Logger.Error("failed to create attribute count processor", zap.Error(err))
+			return nil, err
+		}
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(countProcessor))
+	}
+
+	providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(spanProcessor))
+
+	if len(closers) > 0 {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(closingProcessor(closers)))
+	}
+
+	return sdktrace.NewTracerProvider(providerOpts...), nil
+}
+
+// newBatchProcessor creates the batch span processor exporting through the exporter with the
+// configured batch size, counting the exported and dropped spans when export metrics are enabled,
+// or the processor exporting each span as it ends when synchronous export is enabled.
+//
+// Parameters:
+//   - o: The resolved installation options
+//   - exp: The span exporter receiving the batches
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The batch span processor
+//   - error: Any error encountered while creating the instruments
+func newBatchProcessor(o *options.Config, exp sdktrace.SpanExporter) (sdktrace.SpanProcessor, error) {
+	if o.SyncExport {
+		return sdktrace.NewSimpleSpanProcessor(exp), nil
+	}
+
+	var batchOpts []sdktrace.BatchSpanProcessorOption
+	if o.MaxExportBatchSize > 0 {
+		batchOpts = append(batchOpts, sdktrace.WithMaxExportBatchSize(o.MaxExportBatchSize))
+	}
+
+	if o.ExportMetricsProvider != nil {
+		return processor.NewBatchWithMetrics(exp, o.ExportMetricsProvider, batchOpts...)
+	}
+
+	return sdktrace.NewBatchSpanProcessor(exp, batchOpts...), nil
+}
+
+// baseSampler returns the sampler set in the options or, when none is set, the sampler of
+// the application environment, sampling every span for environments without one.
+//
+// Parameters:
+//   - cfgs: Application configurations including the environment
+//   - o: The resolved installation options
+//
+// Returns:
+//   - sdktrace.Sampler: The sampler the other samplers are composed with
+func baseSampler(cfgs *configs.Configs, o *options.Config) sdktrace.Sampler {
+	if o.Sampler != nil {
+		return o.Sampler
+	}
+
+	if s, ok := o.EnvironmentSamplers[cfgs.AppConfigs.Environment]; ok && s != nil {
+		return s
+	}
+
+	return sdktrace.AlwaysSample()
+}
+
+// Register stores the tracer provider in the configs and, unless the global registration
+// is disabled by the options, sets it as the global tracer provider together with the
+// propagator set in the options or the propagators listed by OTEL_PROPAGATORS, and routes
+// the errors of the OpenTelemetry SDK, such as failed exports, to the configured logger.
+// A propagator customized by the options, or decorated with resource baggage, is used for
+// AMQP messages as well.
+//
+// Parameters:
+//   - cfgs: Application configurations to store the tracer provider
+//   - o: The resolved installation options
+//   - tracerProvider: The tracer provider to register
+func Register(cfgs *configs.Configs, o *options.Config, tracerProvider *sdktrace.TracerProvider) {
+	cfgs.TracerProvider = tracerProvider
+
+	if o.SkipGlobalRegistration {
+		return
+	}
+
+	otel.SetTracerProvider(tracerProvider)
+
+	textMapPropagator := o.Propagator
+	if textMapPropagator == nil {
+		textMapPropagator = propagation.FromEnv()
+	}
+
+	if len(o.ResourceBaggageKeys) > 0 {
+		res := Resource(cfgs, o.SchemaURL, o.ResourceAttributes...)
+		textMapPropagator = propagation.NewResourceBaggagePropagator(textMapPropagator, res, o.ResourceBaggageKeys...)
+	}
+
+	otel.SetTextMapPropagator(textMapPropagator)
+
+	// The AMQP propagator is built from the same base on every installation, so installing
+	// again replaces it instead of decorating it once more.
+	if o.Propagator != nil || len(o.ResourceBaggageKeys) > 0 {
+		amqppropagator.Set(textMapPropagator)
+	} else {
+		amqppropagator.Set(nil)
+	}
+
+	// The logger is captured now: cfgs.Logger may later be bridged to OTLP logs, whose
+	// export errors would otherwise be logged through the failing exporter again.
+	logger := cfgs.Logger
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		// The spans dropped by an open circuit breaker are counted, not logged: the breaker
+		// exists to stop the error logs of a sustained collector outage.
+		if errors.Is(err, exporter.ErrCircuitOpen) {
+			return
+		}
+
+		logger.Error("opentelemetry error", zap.Error(err))
+	}))
+}
+
+// Resource builds the resource describing the service, shared by every exported signal.
+// Attributes follow the semantic conventions of semconv.SchemaURL, which is the schema URL
+// of the resource unless another one is given for backends pinned to an older version.
+// The service.instance.id attribute is read from the SERVICE_INSTANCE_ID environment variable,
+// or generated once per process, so replicas can be told apart; extra attributes override it.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - schemaURL: The schema URL of the resource, empty for semconv.SchemaURL
+//   - extra: Additional resource attributes
+//
+// Returns:
+//   - *resource.Resource: The service resource
+func Resource(cfgs *configs.Configs, schemaURL string, extra ...attribute.KeyValue) *resource.Resource {
+	if schemaURL == "" {
+		schemaURL = semconv.SchemaURL
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(cfgs.AppConfigs.Name),
+		semconv.ServiceNamespaceKey.String(cfgs.AppConfigs.Namespace),
+		semconv.ServiceInstanceIDKey.String(instanceID),
+		attribute.String("service.environment", cfgs.AppConfigs.Environment.String()),
+		semconv.DeploymentEnvironmentNameKey.String(cfgs.AppConfigs.Environment.String()),
+		semconv.TelemetrySDKLanguageGo,
+	}
+
+	return resource.NewWithAttributes(schemaURL, append(attrs, extra...)...)
+}
+
+// closingProcessor releases resources tied to the tracer provider, such as background
+// pollers, when the provider shuts down.
+type closingProcessor []func()
+
+// OnStart does nothing.
+func (p closingProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd does nothing.
+func (p closingProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown releases the resources.
+func (p closingProcessor) Shutdown(context.Context) error {
+	for _, closeFn := range p {
+		closeFn()
+	}
+
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p closingProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// newInstanceID resolves the service instance ID from the environment, or generates a random one.
+//
+// Returns:
+//   - string: The service instance ID
+func newInstanceID() string {
+	if id := os.Getenv(InstanceIDEnvKey); id != "" {
+		return id
+	}
+
+	return uuid.NewString()
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/amqp"
+	"github.com/goxkit/tracing/exporter"
+	"github.com/goxkit/tracing/internal/amqppropagator"
+	"github.com/goxkit/tracing/options"
+	"github.com/goxkit/tracing/sampler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newTestConfigs returns the configs of a service named orders.
+func newTestConfigs() *configs.Configs {
+	return &configs.Configs{
+		Logger: zap.NewNop(),
+		AppConfigs: &configs.AppConfigs{
+			Name:        "orders",
+			Namespace:   "shop",
+			Environment: configs.StagingEnv,
+		},
+		OTLPConfigs: &configs.OTLPConfigs{},
+	}
+}
+
+// newTestProvider creates a tracer provider with the options, exporting the ended spans to
+// the returned exporter when it is flushed.
+func newTestProvider(t *testing.T, opts ...options.Option) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exp := tracetest.NewInMemoryExporter()
+
+	tp, err := New(newTestConfigs(), options.New(append([]options.Option{options.WithSyncExport()}, opts...)...), exp)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp, exp
+}
+
+func TestNewLayersSamplingRulesOverTheBaseSampler(t *testing.T) {
+	tp, exp := newTestProvider(t,
+		options.WithSampler(sdktrace.AlwaysSample()),
+		options.WithSamplingRules(sampler.Drop("GET /healthz")),
+	)
+
+	for _, name := range []string{"GET /healthz", "GET /orders"} {
+		_, span := tp.Tracer("test").Start(context.Background(), name)
+		span.End()
+	}
+	_ = tp.ForceFlush(context.Background())
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "GET /orders" {
+		t.Errorf("got %d exported spans, want GET /orders only", len(spans))
+	}
+}
+
+func TestNewKeepsSlowSpansDroppedByTheSamplerOnly(t *testing.T) {
+	tp, exp := newTestProvider(t,
+		options.WithSampler(sdktrace.NeverSample()),
+		options.WithSamplingRules(sampler.Drop("GET /healthz")),
+		options.WithLatencyKeep(100*time.Millisecond),
+	)
+
+	for _, name := range []string{"GET /healthz", "GET /orders"} {
+		start := time.Now()
+
+		_, span := tp.Tracer("test").Start(context.Background(), name, trace.WithTimestamp(start))
+		span.End(trace.WithTimestamp(start.Add(time.Second)))
+	}
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "GET /orders" {
+		t.Errorf("got %d exported spans, want the slow GET /orders only", len(spans))
+	}
+}
+
+// batchSizeExporter records the number of spans of each export.
+type batchSizeExporter struct {
+	// mu guards sizes
+	mu sync.Mutex
+
+	// sizes are the numbers of spans of the exports, in order
+	sizes []int
+}
+
+// ExportSpans records the number of spans.
+func (e *batchSizeExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.sizes = append(e.sizes, len(spans))
+
+	return nil
+}
+
+// Shutdown does nothing.
+func (e *batchSizeExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestNewCapsTheExportBatchSize(t *testing.T) {
+	exp := &batchSizeExporter{}
+
+	tp, err := New(newTestConfigs(), options.New(options.WithMaxExportBatchSize(2)), exp)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	for i := 0; i < 5; i++ {
+		_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+		span.End()
+	}
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+
+	total := 0
+	for _, size := range exp.sizes {
+		if size > 2 {
+			t.Errorf("exported a batch of %d spans, want at most 2", size)
+		}
+
+		total += size
+	}
+
+	if total != 5 {
+		t.Errorf("exported %d spans, want 5", total)
+	}
+}
+
+// restoreGlobals restores the global tracer provider, propagator and error handler, and the
+// AMQP propagator, when the test ends.
+func restoreGlobals(t *testing.T) {
+	t.Helper()
+
+	tracerProvider := otel.GetTracerProvider()
+	textMapPropagator := otel.GetTextMapPropagator()
+	errorHandler := otel.GetErrorHandler()
+	amqpPropagator := amqppropagator.Get()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(textMapPropagator)
+		otel.SetErrorHandler(errorHandler)
+		amqppropagator.Set(amqpPropagator)
+	})
+}
+
+func TestRegisterInjectsResourceBaggage(t *testing.T) {
+	restoreGlobals(t)
+
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	// Registering again must replace the propagators, not decorate them once more.
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		Register(newTestConfigs(), options.New(
+			options.WithPropagator(propagation.Baggage{}),
+			options.WithResourceAttributes(attribute.String("cloud.region", region)),
+			options.WithResourceBaggage("cloud.region"),
+		), tp)
+	}
+
+	global := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(context.Background(), global)
+
+	for name, carrier := range map[string]propagation.TextMapCarrier{
+		"global": global,
+		"amqp":   amqp.AMQPHeader(amqp.InjectAMQP(context.Background(), nil)),
+	} {
+		if keys := carrier.Keys(); len(keys) != 1 || keys[0] != "baggage" {
+			t.Errorf("%s: injected headers = %v, want the baggage of the configured propagator only", name, keys)
+		}
+
+		bag, err := baggage.Parse(carrier.Get("baggage"))
+		if err != nil {
+			t.Fatalf("%s: parsing the injected baggage: %v", name, err)
+		}
+
+		if got := bag.Member("cloud.region").Value(); got != "eu-west-1" || bag.Len() != 1 {
+			t.Errorf("%s: baggage = %s, want cloud.region=eu-west-1 only", name, bag)
+		}
+	}
+
+	Register(newTestConfigs(), options.New(), tp)
+
+	if p := amqppropagator.Get(); p != nil {
+		t.Errorf("AMQP propagator = %T after an installation without options, want the amqp default", p)
+	}
+}
+
+// failingExporter fails every export with its error.
+type failingExporter struct {
+	// err is the error returned by every export
+	err error
+}
+
+// ExportSpans fails with the error of the exporter.
+func (e failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return e.err
+}
+
+// Shutdown does nothing.
+func (failingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestRegisterLogsExportErrorsThroughTheConfiguredLogger(t *testing.T) {
+	restoreGlobals(t)
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	cfgs := newTestConfigs()
+	cfgs.Logger = zap.New(core)
+
+	for _, exportErr := range []error{
+		errors.New("collector unavailable"),
+		fmt.Errorf("exporting: %w", exporter.ErrCircuitOpen),
+	} {
+		o := options.New(options.WithSyncExport())
+
+		tp, err := New(cfgs, o, failingExporter{err: exportErr})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		Register(cfgs, o, tp)
+
+		_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+		span.End()
+		_ = tp.Shutdown(context.Background())
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d error logs, want the export error only, not the open circuit", len(entries))
+	}
+
+	if got := entries[0].ContextMap()["error"]; got != "collector unavailable" {
+		t.Errorf("logged error = %v, want collector unavailable", got)
+	}
+}
+
+func TestNewInstanceIDFromEnvironment(t *testing.T) {
+	t.Setenv(InstanceIDEnvKey, "orders-0")
+
+	if got := newInstanceID(); got != "orders-0" {
+		t.Errorf("newInstanceID = %q, want the environment value orders-0", got)
+	}
+
+	t.Setenv(InstanceIDEnvKey, "")
+
+	if first, second := newInstanceID(), newInstanceID(); first == "" || first == second {
+		t.Errorf("generated IDs %q and %q, want distinct random IDs", first, second)
+	}
+}
+
+func TestNewSetsAStableServiceInstanceID(t *testing.T) {
+	tp, exp := newTestProvider(t)
+
+	for _, name := range []string{"orders", "payments"} {
+		_, span := tp.Tracer(name).Start(context.Background(), "orders.create")
+		span.End()
+	}
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	for _, stub := range exp.GetSpans() {
+		if got, _ := stub.Resource.Set().Value("service.instance.id"); got.AsString() != instanceID || instanceID == "" {
+			t.Errorf("service.instance.id = %q, want the process instance ID %q", got.AsString(), instanceID)
+		}
+	}
+
+	tp, exp = newTestProvider(t, options.WithServiceInstanceID("orders-1"))
+
+	_, span := tp.Tracer("orders").Start(context.Background(), "orders.create")
+	span.End()
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	if got, _ := exp.GetSpans()[0].Resource.Set().Value("service.instance.id"); got.AsString() != "orders-1" {
+		t.Errorf("service.instance.id = %q, want the configured orders-1", got.AsString())
+	}
+}
+
+func TestResourceUsesTheSemconvSchemaURLByDefault(t *testing.T) {
+	res := Resource(newTestConfigs(), "")
+	if res.SchemaURL() != semconv.SchemaURL {
+		t.Errorf("schema URL = %s, want %s", res.SchemaURL(), semconv.SchemaURL)
+	}
+
+	if env, ok := res.Set().Value(semconv.DeploymentEnvironmentNameKey); !ok || env.AsString() != newTestConfigs().AppConfigs.Environment.String() {
+		t.Errorf("%s = %v, want the environment of the configs", semconv.DeploymentEnvironmentNameKey, env)
+	}
+}
+
+func TestNewSetsTheConfiguredSchemaURL(t *testing.T) {
+	const schemaURL = "https://opentelemetry.io/schemas/1.26.0"
+
+	tp, exp := newTestProvider(t, options.WithSchemaURL(schemaURL))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d exported spans, want 1", len(spans))
+	}
+
+	if got := spans[0].Resource.SchemaURL(); got != schemaURL {
+		t.Errorf("schema URL = %s, want %s", got, schemaURL)
+	}
+}
+
+// sequentialIDs generates the trace ID 1 and increasing span IDs.
+type sequentialIDs struct {
+	// mu guards next
+	mu sync.Mutex
+
+	// next is the last byte of the next span ID
+	next byte
+}
+
+// NewIDs returns the trace ID 1 and the next span ID.
+func (g *sequentialIDs) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	return trace.TraceID{15: 1}, g.NewSpanID(context.Background(), trace.TraceID{})
+}
+
+// NewSpanID returns the next span ID.
+func (g *sequentialIDs) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.next++
+
+	return trace.SpanID{7: g.next}
+}
+
+func TestNewUsesTheConfiguredIDGenerator(t *testing.T) {
+	tp, exp := newTestProvider(t, options.WithIDGenerator(&sequentialIDs{}))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "orders.create")
+	_, child := tp.Tracer("test").Start(ctx, "orders.pay")
+	child.End()
+	parent.End()
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d exported spans, want 2", len(spans))
+	}
+
+	want := map[string]string{"orders.create": "0000000000000001", "orders.pay": "0000000000000002"}
+	for _, span := range spans {
+		if got := span.SpanContext.TraceID().String(); got != "00000000000000000000000000000001" {
+			t.Errorf("%s trace ID = %s, want the generated one", span.Name, got)
+		}
+
+		if got := span.SpanContext.SpanID().String(); got != want[span.Name] {
+			t.Errorf("%s span ID = %s, want %s", span.Name, got, want[span.Name])
+		}
+	}
+}
+
+func TestBaseSamplerFollowsTheEnvironment(t *testing.T) {
+	production := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.ProductionSamplingRatio)).Description()
+
+	tests := []struct {
+		env  configs.Environment
+		opts []options.Option
+		want string
+	}{
+		{env: configs.ProductionEnv, want: production},
+		{env: configs.StagingEnv, want: sdktrace.AlwaysSample().Description()},
+		{env: configs.LocalEnv, want: sdktrace.AlwaysSample().Description()},
+		{
+			env:  configs.StagingEnv,
+			opts: []options.Option{options.WithEnvironmentSamplers(map[configs.Environment]sdktrace.Sampler{configs.StagingEnv: sdktrace.NeverSample()})},
+			want: sdktrace.NeverSample().Description(),
+		},
+		{
+			env:  configs.ProductionEnv,
+			opts: []options.Option{options.WithEnvironmentSamplers(map[configs.Environment]sdktrace.Sampler{configs.StagingEnv: sdktrace.NeverSample()})},
+			want: production,
+		},
+		{
+			env:  configs.ProductionEnv,
+			opts: []options.Option{options.WithSampler(sdktrace.NeverSample())},
+			want: sdktrace.NeverSample().Description(),
+		},
+	}
+
+	for _, tt := range tests {
+		cfgs := newTestConfigs()
+		cfgs.AppConfigs.Environment = tt.env
+
+		if got := baseSampler(cfgs, options.New(tt.opts...)).Description(); got != tt.want {
+			t.Errorf("%s: sampler = %s, want %s", tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// JobNameKey is the attribute key holding the name of a background job
+	JobNameKey = attribute.Key("job.name")
+
+	// JobRunIDKey is the attribute key identifying a single run of a background job
+	JobRunIDKey = attribute.Key("job.run_id")
+)
+
+// NewJobSpan starts the root span of a background job run, such as a cron job or a
+// time.Timer callback, so every sub-operation of the run belongs to a single trace. The span
+// is always a new root with the internal kind, and carries the job.name attribute and the
+// job.run_id attribute, set to the trace ID of the run, to find the runs of a job in the
+// backend and correlate them with their logs. The returned context is not cancelled: bound
+// it with context.WithTimeout if the run must be time-boxed.
+//
+// Example usage:
+//
+//	c.AddFunc("@hourly", func() {
+//		ctx, span := tracing.NewJobSpan("invoices.reconcile")
+//		defer span.End()
+//
+//		reconcile(ctx)
+//	})
+//
+// Parameters:
+//   - name: The job name, also used as the span name
+//
+// Returns:
+//   - context.Context: Context containing the job span
+//   - trace.Span: The root span of the job run
+func NewJobSpan(name string) (context.Context, trace.Span) {
+	ctx, span := Tracer("").Start(context.Background(), name,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(JobNameKey.String(name)),
+	)
+
+	if spanCtx := span.SpanContext(); spanCtx.IsValid() {
+		span.SetAttributes(JobRunIDKey.String(spanCtx.TraceID().String()))
+	}
+
+	return ctx, span
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewJobSpanStartsARootSpanWithTheJobAttributes(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, span := NewJobSpan("invoices.reconcile")
+	_, child := Tracer("").Start(ctx, "invoices.fetch")
+	child.End()
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d ended spans, want 2", len(ended))
+	}
+
+	job := ended[1]
+	if job.Parent().IsValid() {
+		t.Error("the job span has a parent")
+	}
+
+	if job.SpanKind() != trace.SpanKindInternal {
+		t.Errorf("kind = %s, want internal", job.SpanKind())
+	}
+
+	if !hasAttribute(job.Attributes(), JobNameKey.String("invoices.reconcile")) {
+		t.Errorf("%s missing, want the job name", JobNameKey)
+	}
+
+	if !hasAttribute(job.Attributes(), JobRunIDKey.String(job.SpanContext().TraceID().String())) {
+		t.Errorf("%s missing, want the trace ID", JobRunIDKey)
+	}
+
+	if ended[0].Parent().SpanID() != job.SpanContext().SpanID() {
+		t.Error("the sub-operation is not a child of the job span")
+	}
+}
+
+func TestNewJobSpanStartsATracePerRun(t *testing.T) {
+	installTestProvider(t)
+
+	_, first := NewJobSpan("invoices.reconcile")
+	first.End()
+
+	_, second := NewJobSpan("invoices.reconcile")
+	second.End()
+
+	if first.SpanContext().TraceID() == second.SpanContext().TraceID() {
+		t.Error("two job runs share a trace")
+	}
+}
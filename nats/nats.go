@@ -0,0 +1,144 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package nats provides utilities for propagating trace context through NATS messages.
+// It mirrors the amqp package, enabling distributed tracing across services that
+// communicate over NATS subjects.
+//
+// The package implements OpenTelemetry's TextMapCarrier interface for NATS headers
+// and provides helpers to start publisher and consumer spans that inject and extract
+// trace context from/to NATS messages.
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// NATSPropagator is a composite propagator that combines TraceContext and Baggage propagation
+	// for NATS messaging contexts. This enables both trace correlation and contextual properties
+	// to be passed between services.
+	NATSPropagator = propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+)
+
+// NATSHeader wraps nats.Header to implement the TextMapCarrier interface for OpenTelemetry propagation.
+// This allows trace context to be injected into and extracted from NATS message headers.
+type NATSHeader nats.Header
+
+// Set sets the value for the given key in the NATS header, replacing any existing values.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Parameters:
+//   - key: The header key
+//   - val: The header value to set
+func (h NATSHeader) Set(key, val string) {
+	h[key] = []string{val}
+}
+
+// Get retrieves the value for a given key from the NATS header. NATS headers may hold
+// multiple values per key, in which case the first value is returned.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Parameters:
+//   - key: The header key to retrieve
+//
+// Returns:
+//   - string: The first header value, or empty string if not found
+func (h NATSHeader) Get(key string) string {
+	values, ok := h[key]
+
+	if !ok || len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Keys returns a sorted list of all keys in the NATS header.
+// This implements part of the TextMapCarrier interface required by OpenTelemetry
+// for context propagation.
+//
+// Returns:
+//   - []string: A sorted slice of all header keys
+func (h NATSHeader) Keys() []string {
+	keys := make([]string, 0, len(h))
+
+	for k := range h {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// NewPublisherSpan creates a new producer span for publishing a NATS message and injects
+// the resulting trace context into the message headers, so the consumer can continue
+// the same trace. The message headers are initialized when nil.
+//
+// Parameters:
+//   - ctx: The parent context of the publish operation
+//   - tracer: The OpenTelemetry tracer to create the span
+//   - msg: The NATS message about to be published
+//
+// Returns:
+//   - context.Context: Context containing the publisher span
+//   - trace.Span: The new span created for this publish operation
+func NewPublisherSpan(ctx context.Context, tracer trace.Tracer, msg *nats.Msg) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(
+		ctx,
+		fmt.Sprintf("publish.%s", msg.Subject),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination", msg.Subject),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+
+	NATSPropagator.Inject(ctx, NATSHeader(msg.Header))
+
+	return ctx, span
+}
+
+// NewConsumerSpan creates a new consumer span for a received NATS message with the trace
+// context extracted from the message headers. This allows continuation of a trace that
+// was started in a publisher service, maintaining the end-to-end transaction context.
+//
+// Parameters:
+//   - tracer: The OpenTelemetry tracer to create the span
+//   - msg: The received NATS message containing the trace context
+//
+// Returns:
+//   - context.Context: Context with the extracted trace information
+//   - trace.Span: The new span created for this consumer operation
+func NewConsumerSpan(tracer trace.Tracer, msg *nats.Msg) (context.Context, trace.Span) {
+	ctx := NATSPropagator.Extract(context.Background(), NATSHeader(msg.Header))
+	return tracer.Start(
+		ctx,
+		fmt.Sprintf("consume.%s", msg.Subject),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination", msg.Subject),
+			attribute.String("messaging.operation", "receive"),
+		),
+	)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package nats
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNATSHeaderCarrier(t *testing.T) {
+	h := NATSHeader(nats.Header{"b": {"2", "3"}})
+	h.Set("a", "1")
+
+	if got := h.Get("a"); got != "1" {
+		t.Errorf("Get(a) = %q, want 1", got)
+	}
+
+	if got := h.Get("b"); got != "2" {
+		t.Errorf("Get(b) = %q, want the first value 2", got)
+	}
+
+	if got := h.Get("missing"); got != "" {
+		t.Errorf("Get(missing) = %q, want empty", got)
+	}
+
+	if got := h.Keys(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Keys() = %v, want [a b]", got)
+	}
+}
+
+func TestPublisherAndConsumerSpansShareTheTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	msg := nats.NewMsg("orders.created")
+	msg.Header = nil
+
+	_, publishSpan := NewPublisherSpan(context.Background(), tracer, msg)
+	publishSpan.End()
+
+	if msg.Header.Get("traceparent") == "" {
+		t.Fatal("traceparent header not injected")
+	}
+
+	_, consumeSpan := NewConsumerSpan(tracer, msg)
+	consumeSpan.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	publish, consume := spans[0], spans[1]
+
+	if publish.Name() != "publish.orders.created" || publish.SpanKind() != trace.SpanKindProducer {
+		t.Errorf("publisher span = %s (%s), want publish.orders.created (producer)", publish.Name(), publish.SpanKind())
+	}
+
+	if consume.Name() != "consume.orders.created" || consume.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("consumer span = %s (%s), want consume.orders.created (consumer)", consume.Name(), consume.SpanKind())
+	}
+
+	if consume.Parent().SpanID() != publish.SpanContext().SpanID() {
+		t.Error("consumer span is not a child of the publisher span")
+	}
+
+	if consume.SpanContext().TraceID() != publish.SpanContext().TraceID() {
+		t.Error("consumer span is not in the trace of the publisher span")
+	}
+}
@@ -10,6 +10,8 @@ package noop
 
 import (
 	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/internal/provider"
+	"github.com/goxkit/tracing/options"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -19,16 +21,41 @@ import (
 // overhead should be avoided but code that expects a tracer should still work.
 //
 // The tracer provider is stored in the configs object for use throughout
-// the application.
+// the application. An ID generator set with options.WithIDGenerator is used,
+// so the IDs found in contexts, e.g. in logs, stay predictable in tests.
 //
 // Parameters:
 //   - cfgs: Application configurations to store the tracer provider
+//   - opts: Installation options; exporter-related options have no effect
 //
 // Returns:
 //   - *sdktrace.TracerProvider: A minimal tracer provider with no exporters
 //   - error: Always nil for the noop implementation
-func Install(cfgs *configs.Configs) (*sdktrace.TracerProvider, error) {
-	provider := sdktrace.NewTracerProvider()
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	var providerOpts []sdktrace.TracerProviderOption
+	if o := options.New(opts...); o.IDGenerator != nil {
+		providerOpts = append(providerOpts, sdktrace.WithIDGenerator(o.IDGenerator))
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOpts...)
 	cfgs.TracerProvider = provider
 	return provider, nil
 }
+
+// NewProvider creates a tracer provider exporting nothing like Install, without registering
+// it in the configs. Its spans are described by the resource attributes of the options, such
+// as the tenant of a tenant provider, so code inspecting them sees the same resource as with
+// an exporter.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options; only the resource attributes are used
+//
+// Returns:
+//   - *sdktrace.TracerProvider: A tracer provider with no exporters
+//   - error: Always nil for the noop implementation
+func NewProvider(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	o := options.New(opts...)
+
+	return sdktrace.NewTracerProvider(sdktrace.WithResource(provider.Resource(cfgs, o.SchemaURL, o.ResourceAttributes...))), nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package noop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fixedIDs generates the same trace and span IDs for every span.
+type fixedIDs struct{}
+
+// NewIDs returns the trace ID 1 and the span ID 1.
+func (fixedIDs) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	return trace.TraceID{15: 1}, trace.SpanID{7: 1}
+}
+
+// NewSpanID returns the span ID 1.
+func (fixedIDs) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return trace.SpanID{7: 1}
+}
+
+func TestInstallUsesTheConfiguredIDGenerator(t *testing.T) {
+	cfgs := &configs.Configs{}
+
+	tp, err := Install(cfgs, options.WithIDGenerator(fixedIDs{}))
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	if cfgs.TracerProvider != tp {
+		t.Error("the tracer provider is not stored in the configs")
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if got := span.SpanContext().TraceID().String(); got != "00000000000000000000000000000001" {
+		t.Errorf("trace ID = %s, want the generated one", got)
+	}
+}
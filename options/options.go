@@ -0,0 +1,1014 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package options provides the functional options accepted by the tracing installers.
+// Settings that are not part of the shared configs package (export retry policy,
+// connection behavior, etc.) are expressed as options so they can be passed uniformly
+// to tracing.Install or directly to a specific installer such as otlp.Install.
+package options
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/processor"
+	"github.com/goxkit/tracing/sampler"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// NoCompression disables compression of exported payloads
+	NoCompression = "none"
+
+	// GzipCompression compresses exported payloads with gzip
+	GzipCompression = "gzip"
+
+	// ProductionSamplingRatio is the fraction of traces sampled by default in production
+	ProductionSamplingRatio = 0.1
+)
+
+// RetryConfig defines the retry policy applied when exporting spans fails.
+type RetryConfig struct {
+	// Enabled indicates whether failed exports are retried
+	Enabled bool
+
+	// InitialInterval is the time to wait after the first failure before retrying
+	InitialInterval time.Duration
+
+	// MaxInterval is the upper bound of the backoff interval between retries
+	MaxInterval time.Duration
+
+	// MaxElapsedTime is the maximum time spent retrying a batch before it is dropped
+	MaxElapsedTime time.Duration
+}
+
+// JaegerRemoteConfig defines the Jaeger sampling server providing the sampling strategy.
+type JaegerRemoteConfig struct {
+	// ServerURL is the URL of the sampling strategies endpoint, e.g. http://jaeger-agent:5778/sampling
+	ServerURL string
+
+	// ServiceName is the service whose strategy is fetched, the application name when empty
+	ServiceName string
+
+	// RefreshInterval is the interval between two fetches of the strategy
+	RefreshInterval time.Duration
+}
+
+// CircuitBreakerConfig defines the circuit breaker protecting the exporter, enabled when the
+// failure threshold is positive.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed exports opening the breaker
+	FailureThreshold int
+
+	// Cooldown is the time the breaker stays open before probing the exporter
+	Cooldown time.Duration
+}
+
+// Config holds the settings resolved from the options passed to an installer.
+type Config struct {
+	// Retry is the retry policy for failed exports
+	Retry RetryConfig
+
+	// CircuitBreaker stops exporting for a while after consecutive export failures
+	CircuitBreaker CircuitBreakerConfig
+
+	// FailoverEndpoint is the OTLP endpoint receiving the spans the configured endpoint failed
+	// to export, empty for none
+	FailoverEndpoint string
+
+	// SetupTimeout bounds the time spent connecting to the collector during installation
+	SetupTimeout time.Duration
+
+	// LazyConnect lets the exporter connect in the background instead of failing the
+	// installation when the shared gRPC connection cannot be created
+	LazyConnect bool
+
+	// MaxExportBatchSize is the maximum number of spans per export, zero for the SDK default
+	MaxExportBatchSize int
+
+	// Compression is the compression applied to exported payloads (none or gzip)
+	Compression string
+
+	// Exporter names the exporter installed by tracing.Install, empty to use TRACING_EXPORTER
+	Exporter string
+
+	// ZipkinEndpoint is the URL of the Zipkin span collection endpoint, empty for the default
+	ZipkinEndpoint string
+
+	// StdoutWriter is the writer the stdout exporter writes spans to, nil for the standard output
+	StdoutWriter io.Writer
+
+	// StdoutCompact writes each span as compact single-line JSON instead of pretty-printed JSON
+	StdoutCompact bool
+
+	// StdoutWithoutTimestamps omits the start and end times of the spans written by the stdout exporter
+	StdoutWithoutTimestamps bool
+
+	// SyncExport exports each span as soon as it ends instead of in batches
+	SyncExport bool
+
+	// FilePath is the path of the file the file exporter writes spans to, empty for the default
+	FilePath string
+
+	// FileMaxSize is the size in bytes at which the file exporter rotates its file, zero to never rotate
+	FileMaxSize int64
+
+	// RedactedAttributes lists the span attribute keys masked before export
+	RedactedAttributes []string
+
+	// AllowedAttributes lists the only span attribute keys exported when set, nil to export every attribute
+	AllowedAttributes []string
+
+	// MaxAttributeLength is the length in bytes above which string attribute values are truncated
+	// before export, zero to disable the truncation
+	MaxAttributeLength int
+
+	// MinSpanDuration is the duration below which spans without error are not exported,
+	// zero to export every span
+	MinSpanDuration time.Duration
+
+	// LatencyKeepThreshold is the duration from which spans dropped by the sampler are
+	// exported anyway, zero to follow the sampling decision
+	LatencyKeepThreshold time.Duration
+
+	// KeepErrorSpans exports the spans with an error status even when the sampler dropped them
+	KeepErrorSpans bool
+
+	// EnvAttributes maps span attribute keys to the environment variables providing their values
+	EnvAttributes map[string]string
+
+	// SkipGlobalRegistration leaves the global tracer provider and propagator untouched
+	SkipGlobalRegistration bool
+
+	// Sampler decides which spans are recorded and exported, nil for the sampler of the
+	// application environment
+	Sampler sdktrace.Sampler
+
+	// EnvironmentSamplers maps application environments to their default sampler, used
+	// when no sampler is set; environments missing from it sample every span
+	EnvironmentSamplers map[configs.Environment]sdktrace.Sampler
+
+	// JaegerRemote configures the Jaeger remote sampler, used when its server URL is set
+	JaegerRemote JaegerRemoteConfig
+
+	// DebugSampling forces the sampling of the spans of contexts marked with sampler.ForceSampling
+	DebugSampling bool
+
+	// DebugBaggageKey is the baggage member also forcing sampling when true, empty to ignore baggage
+	DebugBaggageKey string
+
+	// SamplingRules override the decision of the sampler by span name
+	SamplingRules []sampler.Rule
+
+	// ResourceAttributes are added to the resource describing the service
+	ResourceAttributes []attribute.KeyValue
+
+	// Propagator is the propagator registered as the global propagator and used for AMQP
+	// messages, nil for the ones listed by OTEL_PROPAGATORS
+	Propagator propagation.TextMapPropagator
+
+	// ResourceBaggageKeys lists the resource attributes injected as baggage members by the
+	// registered propagators
+	ResourceBaggageKeys []string
+
+	// SchemaURL is the schema URL of the resource, empty for the one of the semantic conventions in use
+	SchemaURL string
+
+	// Processors are the user stages processing spans before the built-in stages and the export
+	Processors []processor.Stage
+
+	// BaggageAttributes lists the baggage member keys set as attributes on every started span
+	BaggageAttributes []string
+
+	// TenantContextKey is the key of the context value holding the tenant set as the tenant.id
+	// attribute on every started span, nil for none
+	TenantContextKey any
+
+	// HostAttributes are the keys of the host and pod attributes set on every span, if any
+	HostAttributes *processor.HostAttributeKeys
+
+	// EventLogs writes the events of ended spans as log entries through the configured logger
+	EventLogs bool
+
+	// NameCardinalityWarnings logs a warning for span names likely to embed identifiers
+	NameCardinalityWarnings bool
+
+	// MaxSpanLifetime is the lifetime after which spans still open are flagged as leaked and
+	// ended, zero to never end them
+	MaxSpanLifetime time.Duration
+
+	// IDGenerator generates the trace and span IDs, nil for the SDK random generator
+	IDGenerator sdktrace.IDGenerator
+
+	// SpanLimits bounds the attributes, events and links recorded per span
+	SpanLimits sdktrace.SpanLimits
+
+	// ExportMetricsProvider is the meter provider counting exported and dropped spans, if any
+	ExportMetricsProvider metric.MeterProvider
+
+	// SpanMetricsProvider is the meter provider recording RED metrics from spans, if any
+	SpanMetricsProvider metric.MeterProvider
+
+	// AttributeCountMetricsProvider is the meter provider recording the number of attributes
+	// per span, if any
+	AttributeCountMetricsProvider metric.MeterProvider
+
+	// ConnectionMetricsProvider is the meter provider counting the state changes of the
+	// shared exporter connection, if any; the state changes are then also logged
+	ConnectionMetricsProvider metric.MeterProvider
+}
+
+// Option configures an installer.
+type Option func(*Config)
+
+// New creates a Config with the default settings and applies the given options.
+// Defaults honor the standard OpenTelemetry environment variables where applicable
+// (OTEL_EXPORTER_OTLP_TRACES_COMPRESSION, OTEL_EXPORTER_OTLP_COMPRESSION and OTEL_SPAN_*_LIMIT).
+//
+// Parameters:
+//   - opts: The options to apply
+//
+// Returns:
+//   - *Config: The resolved configuration
+func New(opts ...Option) *Config {
+	cfg := &Config{
+		Retry: RetryConfig{
+			Enabled:         true,
+			InitialInterval: 5 * time.Second,
+			MaxInterval:     30 * time.Second,
+			MaxElapsedTime:  time.Minute,
+		},
+		SetupTimeout: 10 * time.Second,
+		Compression:  envCompression(),
+		SpanLimits:   sdktrace.NewSpanLimits(),
+		EnvironmentSamplers: map[configs.Environment]sdktrace.Sampler{
+			configs.ProductionEnv: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ProductionSamplingRatio)),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithRetry enables retrying failed exports with an exponential backoff bounded by the given intervals.
+//
+// Parameters:
+//   - initialInterval: The time to wait after the first failure
+//   - maxInterval: The upper bound of the backoff interval
+//   - maxElapsedTime: The maximum time spent retrying a batch
+//
+// Returns:
+//   - Option: The retry option
+func WithRetry(initialInterval, maxInterval, maxElapsedTime time.Duration) Option {
+	return func(c *Config) {
+		c.Retry = RetryConfig{
+			Enabled:         true,
+			InitialInterval: initialInterval,
+			MaxInterval:     maxInterval,
+			MaxElapsedTime:  maxElapsedTime,
+		}
+	}
+}
+
+// WithoutRetry disables retrying failed exports.
+//
+// Returns:
+//   - Option: The option disabling retries
+func WithoutRetry() Option {
+	return func(c *Config) {
+		c.Retry.Enabled = false
+	}
+}
+
+// WithCircuitBreaker stops exporting after failureThreshold consecutive failed exports,
+// dropping spans right away for the cooldown, then probes the exporter with the next export.
+// It protects the application during sustained collector outages. The breaker state and the
+// spans it drops are reported through the meter provider set with WithExportMetrics, if any;
+// the spans dropped by the breaker are counted as dropped spans, not as exported spans nor
+// failed exports.
+//
+// Parameters:
+//   - failureThreshold: The number of consecutive failures opening the breaker
+//   - cooldown: The time the breaker stays open before probing the exporter
+//
+// Returns:
+//   - Option: The circuit breaker option
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = CircuitBreakerConfig{FailureThreshold: failureThreshold, Cooldown: cooldown}
+	}
+}
+
+// WithFailoverEndpoint sends the spans the configured OTLP endpoint failed to export to a
+// secondary collector, e.g. the second collector of a high-availability pair. Exports always
+// try the configured endpoint first, once and without retries, then the secondary collector
+// with the retry policy; each collector is given the export timeout. The exports delivered by
+// each collector are counted through the meter provider set with WithExportMetrics, if any.
+// It only applies to the OTLP exporter, and the secondary collector uses the TLS and header
+// settings of the configured one.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithFailoverEndpoint("otel-collector-b:4317"))
+//
+// Parameters:
+//   - endpoint: The endpoint of the secondary collector, e.g. otel-collector-b:4317
+//
+// Returns:
+//   - Option: The failover option
+func WithFailoverEndpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.FailoverEndpoint = endpoint
+	}
+}
+
+// WithSetupTimeout bounds the time spent connecting to the collector during installation,
+// so an unreachable endpoint makes the installation fail fast instead of blocking startup.
+//
+// Parameters:
+//   - timeout: The maximum duration of the connection setup
+//
+// Returns:
+//   - Option: The setup timeout option
+func WithSetupTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.SetupTimeout = timeout
+	}
+}
+
+// WithLazyConnect makes the installation tolerate an unavailable collector at startup.
+// When the shared gRPC connection is not ready within the setup timeout, the exporter
+// connects to the configured endpoint in the background and reconnects until the
+// collector is reachable.
+//
+// Returns:
+//   - Option: The lazy connection option
+func WithLazyConnect() Option {
+	return func(c *Config) {
+		c.LazyConnect = true
+	}
+}
+
+// WithMaxExportBatchSize caps the number of spans sent per export, keeping export payloads
+// below the maximum gRPC message size accepted by the collector (4MB by default), which
+// otherwise rejects oversized batches entirely.
+//
+// Parameters:
+//   - size: The maximum number of spans per export
+//
+// Returns:
+//   - Option: The batch size option
+func WithMaxExportBatchSize(size int) Option {
+	return func(c *Config) {
+		c.MaxExportBatchSize = size
+	}
+}
+
+// WithCompression sets the compression applied to exported payloads, overriding the
+// OTEL_EXPORTER_OTLP_COMPRESSION environment variable.
+//
+// Parameters:
+//   - compression: The compression to apply (NoCompression or GzipCompression)
+//
+// Returns:
+//   - Option: The compression option
+func WithCompression(compression string) Option {
+	return func(c *Config) {
+		c.Compression = compression
+	}
+}
+
+// envCompression resolves the compression from the standard environment variables,
+// giving precedence to the traces-specific variable.
+func envCompression() string {
+	for _, key := range []string{"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION", "OTEL_EXPORTER_OTLP_COMPRESSION"} {
+		if value := strings.ToLower(strings.TrimSpace(os.Getenv(key))); value != "" {
+			return value
+		}
+	}
+
+	return NoCompression
+}
+
+// WithExporter selects the exporter installed by tracing.Install and tracing.RegisterTenant
+// ("otlp", "zipkin", "stdout", "file" or "noop"), overriding the TRACING_EXPORTER environment variable.
+//
+// Parameters:
+//   - name: The name of the exporter
+//
+// Returns:
+//   - Option: The exporter selection option
+func WithExporter(name string) Option {
+	return func(c *Config) {
+		c.Exporter = name
+	}
+}
+
+// WithZipkinEndpoint sets the URL of the Zipkin span collection endpoint used by the zipkin
+// installer, overriding the OTEL_EXPORTER_ZIPKIN_ENDPOINT environment variable.
+//
+// Parameters:
+//   - endpoint: The collection endpoint, e.g. http://zipkin:9411/api/v2/spans
+//
+// Returns:
+//   - Option: The Zipkin endpoint option
+func WithZipkinEndpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.ZipkinEndpoint = endpoint
+	}
+}
+
+// WithStdoutWriter makes the stdout exporter write spans to the given writer instead of
+// the standard output, e.g. a buffer in tests or the input of a log-shipping agent.
+//
+// Parameters:
+//   - w: The writer receiving the spans
+//
+// Returns:
+//   - Option: The stdout writer option
+func WithStdoutWriter(w io.Writer) Option {
+	return func(c *Config) {
+		c.StdoutWriter = w
+	}
+}
+
+// WithStdoutCompact makes the stdout exporter write each span as a single line of JSON
+// instead of pretty-printed JSON, for agents parsing newline-delimited JSON.
+//
+// Returns:
+//   - Option: The compact output option
+func WithStdoutCompact() Option {
+	return func(c *Config) {
+		c.StdoutCompact = true
+	}
+}
+
+// WithoutStdoutTimestamps makes the stdout exporter omit the start and end times of the
+// spans, e.g. to compare the output with golden files.
+//
+// Returns:
+//   - Option: The option omitting timestamps
+func WithoutStdoutTimestamps() Option {
+	return func(c *Config) {
+		c.StdoutWithoutTimestamps = true
+	}
+}
+
+// WithSyncExport exports each span synchronously as soon as it ends, instead of in batches
+// in the background, so the output is available right away, e.g. in tests. It slows down the
+// code ending spans and is not meant for production. Export metrics are not recorded.
+//
+// Returns:
+//   - Option: The synchronous export option
+func WithSyncExport() Option {
+	return func(c *Config) {
+		c.SyncExport = true
+	}
+}
+
+// WithFilePath sets the path of the file the file exporter writes spans to, overriding the
+// TRACING_FILE_PATH environment variable.
+//
+// Parameters:
+//   - path: The path of the spans file, e.g. /var/log/traces/orders.ndjson
+//
+// Returns:
+//   - Option: The file path option
+func WithFilePath(path string) Option {
+	return func(c *Config) {
+		c.FilePath = path
+	}
+}
+
+// WithFileMaxSize makes the file exporter rotate its file once it reaches the given size:
+// the full file is renamed with a timestamp and spans are written to a new one.
+//
+// Parameters:
+//   - maxSize: The maximum size of a spans file in bytes
+//
+// Returns:
+//   - Option: The file rotation option
+func WithFileMaxSize(maxSize int64) Option {
+	return func(c *Config) {
+		c.FileMaxSize = maxSize
+	}
+}
+
+// WithRedactedAttributes masks the value of the given span attribute keys before spans are exported.
+//
+// Parameters:
+//   - keys: The attribute keys to redact
+//
+// Returns:
+//   - Option: The redaction option
+func WithRedactedAttributes(keys ...string) Option {
+	return func(c *Config) {
+		c.RedactedAttributes = append(c.RedactedAttributes, keys...)
+	}
+}
+
+// WithAttributeAllowlist exports only the span attributes whose key is listed, dropping every
+// other attribute before export; without keys, every attribute is dropped. Calling it several
+// times extends the allowlist.
+//
+// Parameters:
+//   - keys: The attribute keys allowed through, e.g. "http.request.method"
+//
+// Returns:
+//   - Option: The attribute allowlist option
+func WithAttributeAllowlist(keys ...string) Option {
+	return func(c *Config) {
+		if c.AllowedAttributes == nil {
+			c.AllowedAttributes = []string{}
+		}
+
+		c.AllowedAttributes = append(c.AllowedAttributes, keys...)
+	}
+}
+
+// WithAttributeTruncation shortens string attribute values longer than maxLength bytes before
+// spans are exported, appending an ellipsis and flagging the span with truncated=true.
+//
+// Parameters:
+//   - maxLength: The maximum length in bytes of string attribute values
+//
+// Returns:
+//   - Option: The truncation option
+func WithAttributeTruncation(maxLength int) Option {
+	return func(c *Config) {
+		c.MaxAttributeLength = maxLength
+	}
+}
+
+// WithMinSpanDuration drops the spans lasting less than minDuration at export time, unless
+// they have an error status.
+//
+// Parameters:
+//   - minDuration: The duration below which spans are dropped, e.g. time.Millisecond
+//
+// Returns:
+//   - Option: The minimum span duration option
+func WithMinSpanDuration(minDuration time.Duration) Option {
+	return func(c *Config) {
+		c.MinSpanDuration = minDuration
+	}
+}
+
+// WithLatencyKeep exports the spans lasting at least threshold even when the sampler dropped
+// them, so slow operations are always visible; spans dropped by sampling rules stay dropped.
+// Dropped spans are then recorded rather than discarded, which costs as much as recording
+// sampled ones.
+//
+// Parameters:
+//   - threshold: The duration from which spans are always exported, e.g. 2*time.Second
+//
+// Returns:
+//   - Option: The latency keep option
+func WithLatencyKeep(threshold time.Duration) Option {
+	return func(c *Config) {
+		c.LatencyKeepThreshold = threshold
+	}
+}
+
+// WithErrorKeep exports the spans ending with an error status even when the sampler dropped
+// them, so failures are always visible. Dropped spans are then recorded rather than discarded,
+// which costs as much as recording sampled ones.
+//
+// Returns:
+//   - Option: The error keep option
+func WithErrorKeep() Option {
+	return func(c *Config) {
+		c.KeepErrorSpans = true
+	}
+}
+
+// WithEnvAttributes sets attributes read from environment variables on every span,
+// such as the commit SHA or build version of the deployment.
+//
+// Parameters:
+//   - mapping: The environment variable name to read, indexed by attribute key
+//
+// Returns:
+//   - Option: The environment attributes option
+func WithEnvAttributes(mapping map[string]string) Option {
+	return func(c *Config) {
+		if c.EnvAttributes == nil {
+			c.EnvAttributes = map[string]string{}
+		}
+
+		for key, env := range mapping {
+			c.EnvAttributes[key] = env
+		}
+	}
+}
+
+// WithoutGlobalRegistration leaves the global tracer provider and propagator untouched, so
+// the installed provider is only stored in the configs and returned for local use. It avoids
+// clobbering the telemetry setup of a host application embedding this package.
+//
+// Returns:
+//   - Option: The option disabling the global registration
+func WithoutGlobalRegistration() Option {
+	return func(c *Config) {
+		c.SkipGlobalRegistration = true
+	}
+}
+
+// WithSampler sets the sampler deciding which spans are recorded and exported,
+// replacing the default sampler of the application environment.
+//
+// Parameters:
+//   - sampler: The sampler to use
+//
+// Returns:
+//   - Option: The sampler option
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(c *Config) {
+		c.Sampler = sampler
+	}
+}
+
+// WithEnvironmentSamplers sets the default sampler of application environments, encoding
+// an organization sampling policy in one place instead of per service. The sampler of the
+// environment found in the configs is used unless one is set with WithSampler, and
+// environments missing from the mapping sample every span. The given mapping is merged into
+// the default one, which samples ProductionSamplingRatio of the traces in production, honoring
+// the decision of the parent span, and every span elsewhere.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithEnvironmentSamplers(map[configs.Environment]sdktrace.Sampler{
+//		configs.ProductionEnv: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.01)),
+//		configs.StagingEnv:    sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.5)),
+//	}))
+//
+// Parameters:
+//   - samplers: The default sampler of each environment
+//
+// Returns:
+//   - Option: The environment samplers option
+func WithEnvironmentSamplers(samplers map[configs.Environment]sdktrace.Sampler) Option {
+	return func(c *Config) {
+		for env, sampler := range samplers {
+			c.EnvironmentSamplers[env] = sampler
+		}
+	}
+}
+
+// WithJaegerRemoteSampler applies the sampling strategy served by a Jaeger sampling server,
+// refreshed periodically so sampling rates can be changed without redeploying. The sampler
+// configured with WithSampler decides until a strategy is fetched, and for strategies that
+// are not probabilistic.
+//
+// Parameters:
+//   - serverURL: The URL of the sampling strategies endpoint, e.g. http://jaeger-agent:5778/sampling
+//   - serviceName: The service whose strategy is fetched, the application name when empty
+//   - refreshInterval: The interval between two fetches, sampler.DefaultJaegerRefreshInterval when zero
+//
+// Returns:
+//   - Option: The Jaeger remote sampler option
+func WithJaegerRemoteSampler(serverURL, serviceName string, refreshInterval time.Duration) Option {
+	return func(c *Config) {
+		c.JaegerRemote = JaegerRemoteConfig{
+			ServerURL:       serverURL,
+			ServiceName:     serviceName,
+			RefreshInterval: refreshInterval,
+		}
+	}
+}
+
+// WithDebugSampling forces the sampling of the spans started from a context marked with
+// sampler.ForceSampling, such as requests carrying the X-Debug-Trace header with the http
+// package DebugTrace middleware, or from a context whose baggage holds the baggageKey member
+// with a true value. It overrides the sampler and the sampling rules.
+//
+// Parameters:
+//   - baggageKey: The baggage member forcing sampling when true, e.g. "debug.trace", empty to ignore baggage
+//
+// Returns:
+//   - Option: The debug sampling option
+func WithDebugSampling(baggageKey string) Option {
+	return func(c *Config) {
+		c.DebugSampling = true
+		c.DebugBaggageKey = baggageKey
+	}
+}
+
+// WithSamplingRules overrides the decision of the configured sampler for the spans whose
+// name matches the rules, e.g. to never trace health checks. Rules are evaluated in order.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithSamplingRules(
+//		sampler.Drop("*/healthz"),
+//		sampler.Ratio("GET /search*", 0.1),
+//	))
+//
+// Parameters:
+//   - rules: The sampling rules
+//
+// Returns:
+//   - Option: The sampling rules option
+func WithSamplingRules(rules ...sampler.Rule) Option {
+	return func(c *Config) {
+		c.SamplingRules = append(c.SamplingRules, rules...)
+	}
+}
+
+// WithResourceAttributes adds attributes to the resource describing the service,
+// next to the service name, namespace and environment.
+//
+// Parameters:
+//   - attrs: The resource attributes to add
+//
+// Returns:
+//   - Option: The resource attributes option
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(c *Config) {
+		c.ResourceAttributes = append(c.ResourceAttributes, attrs...)
+	}
+}
+
+// WithPropagator sets the propagator registered as the global propagator and used for AMQP
+// messages, in place of the ones listed by OTEL_PROPAGATORS. The installation replaces the
+// global propagator, so a custom propagator must be passed with this option rather than set
+// beforehand.
+//
+// Parameters:
+//   - p: The propagator to register
+//
+// Returns:
+//   - Option: The propagator option
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *Config) {
+		c.Propagator = p
+	}
+}
+
+// WithResourceBaggage injects the given attributes of the resource describing the service,
+// such as cloud.region, as baggage members of every outbound HTTP request and AMQP message,
+// so downstream services can record them as well. Members already present in the baggage
+// are left untouched. It has no effect with WithoutGlobalRegistration, and on AMQP messages
+// when a propagator is set with amqp.SetPropagator. Calling it several times extends the
+// list of propagated attributes.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs,
+//		options.WithResourceAttributes(attribute.String("cloud.region", region)),
+//		options.WithResourceBaggage("cloud.region"),
+//	)
+//
+// Parameters:
+//   - keys: The resource attribute keys propagated as baggage
+//
+// Returns:
+//   - Option: The resource baggage option
+func WithResourceBaggage(keys ...string) Option {
+	return func(c *Config) {
+		c.ResourceBaggageKeys = append(c.ResourceBaggageKeys, keys...)
+	}
+}
+
+// WithSchemaURL sets the schema URL of the resource describing the service, which defaults to
+// the one of the semantic conventions the resource attributes follow. Backends use it to
+// interpret the attributes, so it should only be overridden to match the version they expect.
+//
+// Parameters:
+//   - schemaURL: The schema URL, e.g. https://opentelemetry.io/schemas/1.26.0
+//
+// Returns:
+//   - Option: The schema URL option
+func WithSchemaURL(schemaURL string) Option {
+	return func(c *Config) {
+		c.SchemaURL = schemaURL
+	}
+}
+
+// WithProcessors adds stages processing the ended spans, in the given order, before the
+// built-in stages (short span filtering, truncation and redaction) and the export. Since the
+// built-in redaction runs last, attributes added by these stages are redacted as well.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithProcessors(
+//		processor.Enrich(processor.NewHost(processor.DefaultHostAttributeKeys)),
+//		processor.Truncate(4096),
+//	))
+//
+// Parameters:
+//   - stages: The stages, in processing order
+//
+// Returns:
+//   - Option: The processors option
+func WithProcessors(stages ...processor.Stage) Option {
+	return func(c *Config) {
+		c.Processors = append(c.Processors, stages...)
+	}
+}
+
+// WithBaggageAttributes sets the baggage members with the given keys as attributes on every
+// started span, so propagated request metadata such as the tenant can be queried in the backend.
+// Calling it several times extends the list of promoted keys.
+//
+// Parameters:
+//   - keys: The baggage member keys promoted to attributes, e.g. "tenant.id"
+//
+// Returns:
+//   - Option: The baggage attributes option
+func WithBaggageAttributes(keys ...string) Option {
+	return func(c *Config) {
+		c.BaggageAttributes = append(c.BaggageAttributes, keys...)
+	}
+}
+
+// WithTenantAttribute sets the tenant found in the context of every started span, under the
+// given context key, as the tenant.id attribute. The tenant is typically stored in the request
+// context by an authentication middleware, as a string or a fmt.Stringer.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithTenantAttribute(auth.TenantKey{}))
+//
+// Parameters:
+//   - contextKey: The key of the context value holding the tenant
+//
+// Returns:
+//   - Option: The tenant attribute option
+func WithTenantAttribute(contextKey any) Option {
+	return func(c *Config) {
+		c.TenantContextKey = contextKey
+	}
+}
+
+// WithHostAttributes sets the host name and, when running in Kubernetes, the pod name on every
+// span, under the given keys (e.g. processor.DefaultHostAttributeKeys).
+//
+// Parameters:
+//   - keys: The attribute keys to use
+//
+// Returns:
+//   - Option: The host attributes option
+func WithHostAttributes(keys processor.HostAttributeKeys) Option {
+	return func(c *Config) {
+		c.HostAttributes = &keys
+	}
+}
+
+// WithEventLogs writes every event of the ended spans as an info log entry through the
+// configured logger, carrying the trace and span IDs. It is disabled by default, as it
+// can significantly increase the log volume.
+//
+// Returns:
+//   - Option: The event logs option
+func WithEventLogs() Option {
+	return func(c *Config) {
+		c.EventLogs = true
+	}
+}
+
+// WithNameCardinalityWarnings logs a warning through the configured logger, once per name
+// pattern, for span names likely to embed identifiers such as numeric IDs or UUIDs. It is
+// meant for development, to catch span names that would explode the cardinality of the backend.
+//
+// Returns:
+//   - Option: The span name warnings option
+func WithNameCardinalityWarnings() Option {
+	return func(c *Config) {
+		c.NameCardinalityWarnings = true
+	}
+}
+
+// WithLeakedSpanWatchdog ends the spans still open after maxLifetime, typically spans whose
+// End call is missing, recording the span.leaked event and an error status on them so the
+// instrumentation bug is visible in the backend.
+//
+// Parameters:
+//   - maxLifetime: The lifetime after which open spans are ended, e.g. 5*time.Minute
+//
+// Returns:
+//   - Option: The leaked span watchdog option
+func WithLeakedSpanWatchdog(maxLifetime time.Duration) Option {
+	return func(c *Config) {
+		c.MaxSpanLifetime = maxLifetime
+	}
+}
+
+// WithServiceInstanceID sets the service.instance.id resource attribute, replacing the ID read
+// from the SERVICE_INSTANCE_ID environment variable or generated for the process.
+//
+// Parameters:
+//   - id: The service instance ID, e.g. the pod UID
+//
+// Returns:
+//   - Option: The service instance ID option
+func WithServiceInstanceID(id string) Option {
+	return WithResourceAttributes(attribute.String("service.instance.id", id))
+}
+
+// WithIDGenerator sets the generator of the trace and span IDs, in place of the random
+// generator of the SDK, e.g. a deterministic generator making the IDs asserted by integration
+// tests reproducible, or one producing IDs compatible with another tracing system.
+//
+// Parameters:
+//   - generator: The ID generator
+//
+// Returns:
+//   - Option: The ID generator option
+func WithIDGenerator(generator sdktrace.IDGenerator) Option {
+	return func(c *Config) {
+		c.IDGenerator = generator
+	}
+}
+
+// WithSpanLimits bounds the number of attributes, events and links recorded per span, and
+// the length of attribute values. Limits left at zero drop the corresponding data entirely
+// and negative limits mean unlimited, so start from sdktrace.NewSpanLimits() to only
+// override some of them.
+//
+// Example usage:
+//
+//	limits := sdktrace.NewSpanLimits()
+//	limits.AttributeCountLimit = 64
+//	limits.AttributeValueLengthLimit = 1024
+//	tracing.Install(cfgs, options.WithSpanLimits(limits))
+//
+// Parameters:
+//   - limits: The span limits to apply
+//
+// Returns:
+//   - Option: The span limits option
+func WithSpanLimits(limits sdktrace.SpanLimits) Option {
+	return func(c *Config) {
+		c.SpanLimits = limits
+	}
+}
+
+// WithSpanMetrics records rate, error and duration (RED) metrics derived from ended spans
+// through the given meter provider (e.g. otel.GetMeterProvider()).
+//
+// Parameters:
+//   - provider: The meter provider recording the metrics
+//
+// Returns:
+//   - Option: The span metrics option
+func WithSpanMetrics(provider metric.MeterProvider) Option {
+	return func(c *Config) {
+		c.SpanMetricsProvider = provider
+	}
+}
+
+// WithAttributeCountMetrics records the number of attributes of every ended span in the
+// span.attribute.count histogram of the given meter provider (e.g. otel.GetMeterProvider()),
+// so runaway instrumentation shows up as a spike before it inflates the export volume.
+//
+// Parameters:
+//   - provider: The meter provider recording the histogram
+//
+// Returns:
+//   - Option: The attribute count metrics option
+func WithAttributeCountMetrics(provider metric.MeterProvider) Option {
+	return func(c *Config) {
+		c.AttributeCountMetricsProvider = provider
+	}
+}
+
+// WithExportMetrics counts the exported spans, the dropped spans and the failed exports
+// through the given meter provider, so span drops caused by a full export queue can be
+// alerted on. Dropped spans are recorded as they are dropped.
+//
+// Parameters:
+//   - provider: The meter provider recording the counters
+//
+// Returns:
+//   - Option: The export metrics option
+func WithExportMetrics(provider metric.MeterProvider) Option {
+	return func(c *Config) {
+		c.ExportMetricsProvider = provider
+	}
+}
+
+// WithConnectionMonitoring watches the shared OTLP exporter connection, logging its state
+// transitions, such as reconnection attempts while the collector flaps, through the configured
+// logger and counting them through the given meter provider (e.g. otel.GetMeterProvider()).
+//
+// Parameters:
+//   - provider: The meter provider recording the state changes
+//
+// Returns:
+//   - Option: The connection monitoring option
+func WithConnectionMonitoring(provider metric.MeterProvider) Option {
+	return func(c *Config) {
+		c.ConnectionMetricsProvider = provider
+	}
+}
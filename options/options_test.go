@@ -0,0 +1,46 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package options
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompressionFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name   string
+		traces string
+		shared string
+		opts   []Option
+		want   string
+	}{
+		{name: "default", want: NoCompression},
+		{name: "shared variable", shared: "GZIP", want: GzipCompression},
+		{name: "traces variable first", traces: "none", shared: "gzip", want: NoCompression},
+		{name: "option overrides", shared: "gzip", opts: []Option{WithCompression(NoCompression)}, want: NoCompression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_COMPRESSION", tt.traces)
+			t.Setenv("OTEL_EXPORTER_OTLP_COMPRESSION", tt.shared)
+
+			if got := New(tt.opts...).Compression; got != tt.want {
+				t.Errorf("Compression = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupTimeout(t *testing.T) {
+	if got := New().SetupTimeout; got != 10*time.Second {
+		t.Errorf("default SetupTimeout = %s, want 10s", got)
+	}
+
+	if got := New(WithSetupTimeout(time.Second)).SetupTimeout; got != time.Second {
+		t.Errorf("SetupTimeout = %s, want 1s", got)
+	}
+}
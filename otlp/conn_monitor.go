@@ -0,0 +1,94 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	// meterName is the instrumentation scope name used for the meters of this package
+	meterName = "github.com/goxkit/tracing/otlp"
+)
+
+var (
+	// monitoredConns holds the exporter connections already monitored
+	monitoredConns sync.Map
+)
+
+// stateWatcher is the part of a gRPC client connection reporting its connectivity state.
+type stateWatcher interface {
+	GetState() connectivity.State
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+}
+
+// monitorConnection starts watching the state of the exporter connection in the background,
+// unless it is already watched. Every transition is logged, as a warning when the connection
+// fails, and counted by the otlp.exporter.connection.state_changes counter with the new state
+// as the state attribute. Watching stops once the connection is closed.
+//
+// Parameters:
+//   - conn: The exporter connection
+//   - logger: The logger writing the transitions
+//   - provider: The meter provider used to create the counter
+//
+// Returns:
+//   - error: Any error encountered while creating the counter
+func monitorConnection(conn *grpc.ClientConn, logger *zap.Logger, provider metric.MeterProvider) error {
+	changes, err := provider.Meter(meterName).Int64Counter(
+		"otlp.exporter.connection.state_changes",
+		metric.WithDescription("Number of connectivity state changes of the OTLP exporter connection"),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, loaded := monitoredConns.LoadOrStore(conn, struct{}{}); loaded {
+		return nil
+	}
+
+	go func() {
+		defer monitoredConns.Delete(conn)
+		watchConnection(conn, logger, changes)
+	}()
+
+	return nil
+}
+
+// watchConnection logs and counts the state transitions of the connection until it is closed.
+//
+// Parameters:
+//   - conn: The watched connection
+//   - logger: The logger writing the transitions
+//   - changes: The counter of state changes
+func watchConnection(conn stateWatcher, logger *zap.Logger, changes metric.Int64Counter) {
+	ctx := context.Background()
+	state := conn.GetState()
+
+	for state != connectivity.Shutdown {
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+
+		next := conn.GetState()
+		fields := []zap.Field{zap.String("from", state.String()), zap.String("to", next.String())}
+
+		if next == connectivity.TransientFailure {
+			logger.Warn("otlp exporter connection failed, reconnecting", fields...)
+		} else {
+			logger.Info("otlp exporter connection state changed", fields...)
+		}
+
+		changes.Add(ctx, 1, metric.WithAttributes(attribute.String("state", next.String())))
+		state = next
+	}
+}
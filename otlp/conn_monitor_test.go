@@ -0,0 +1,112 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/connectivity"
+)
+
+// scriptedConn is a connection going through a fixed sequence of states.
+type scriptedConn struct {
+	// states are the successive states of the connection
+	states []connectivity.State
+}
+
+// GetState returns the current state of the connection.
+func (c *scriptedConn) GetState() connectivity.State {
+	return c.states[0]
+}
+
+// WaitForStateChange moves the connection to its next state.
+func (c *scriptedConn) WaitForStateChange(context.Context, connectivity.State) bool {
+	c.states = c.states[1:]
+	return true
+}
+
+func TestWatchConnectionLogsAndCountsTransitions(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	reader := sdkmetric.NewManualReader()
+
+	changes, err := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter(meterName).Int64Counter("otlp.exporter.connection.state_changes")
+	if err != nil {
+		t.Fatalf("Int64Counter: %v", err)
+	}
+
+	conn := &scriptedConn{states: []connectivity.State{
+		connectivity.Ready,
+		connectivity.TransientFailure,
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.Shutdown,
+	}}
+
+	watchConnection(conn, zap.New(core), changes)
+
+	entries := logs.All()
+	if len(entries) != 4 {
+		t.Fatalf("got %d log entries, want one per transition", len(entries))
+	}
+
+	if entries[0].Level != zapcore.WarnLevel || entries[0].ContextMap()["to"] != "TRANSIENT_FAILURE" {
+		t.Errorf("first entry = %s %v, want a warning about the failure", entries[0].Level, entries[0].ContextMap())
+	}
+
+	if entries[1].Level != zapcore.InfoLevel || entries[1].ContextMap()["from"] != "TRANSIENT_FAILURE" || entries[1].ContextMap()["to"] != "CONNECTING" {
+		t.Errorf("second entry = %s %v, want the reconnection", entries[1].Level, entries[1].ContextMap())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, point := range rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints {
+		state, _ := point.Attributes.Value(attribute.Key("state"))
+		counts[state.AsString()] = point.Value
+	}
+
+	if counts["TRANSIENT_FAILURE"] != 1 || counts["CONNECTING"] != 1 || counts["READY"] != 1 || counts["SHUTDOWN"] != 1 {
+		t.Errorf("state changes = %v, want one per new state", counts)
+	}
+}
+
+func TestMonitorConnectionStopsWatchingClosedConnections(t *testing.T) {
+	collector := newFakeCollector(t)
+
+	conn, err := dialExporter(context.Background(), newTestConfigs(collector.addr), options.New())
+	if err != nil {
+		t.Fatalf("dialExporter: %v", err)
+	}
+
+	if err := monitorConnection(conn, zap.NewNop(), sdkmetric.NewMeterProvider()); err != nil {
+		t.Fatalf("monitorConnection: %v", err)
+	}
+
+	if _, ok := monitoredConns.Load(conn); !ok {
+		t.Fatal("the connection is not monitored")
+	}
+
+	_ = conn.Close()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if _, ok := monitoredConns.Load(conn); !ok {
+			return
+		}
+	}
+
+	t.Error("the closed connection is still monitored")
+}
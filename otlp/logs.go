@@ -0,0 +1,69 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/otel/otlpgrpc"
+	"github.com/goxkit/tracing/internal/provider"
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// InstallLogs configures an OpenTelemetry logger provider that exports log records via OTLP
+// over the same gRPC connection used for traces, and bridges the configured zap logger to it.
+// Every entry written through cfgs.Logger is then also shipped as an OTLP log record, so the
+// observability backend can correlate logs with traces.
+//
+// Log records carry the trace and span IDs of the span found in a context.Context field
+// of the entry, e.g. zap.Any("context", ctx).
+//
+// Parameters:
+//   - cfgs: Application configurations including the OTLP connection and the zap logger
+//
+// Returns:
+//   - *sdklog.LoggerProvider: The configured logger provider with OTLP export capabilities
+//   - error: Any error encountered during setup
+func InstallLogs(cfgs *configs.Configs) (*sdklog.LoggerProvider, error) {
+	ctx := context.Background()
+
+	if isClosed(cfgs.OTLPExporterConn) {
+		cfgs.Logger.Warn("grpc exporter connection is closed, recreating it")
+		cfgs.OTLPExporterConn = nil
+	}
+
+	if cfgs.OTLPExporterConn == nil {
+		conn, err := otlpgrpc.NewExporterGRPCClient(cfgs)
+		if err != nil {
+			cfgs.Logger.Error("failed to create grpc exporter", zap.Error(err))
+			return nil, err
+		}
+		cfgs.OTLPExporterConn = conn
+	}
+
+	exp, err := otlploggrpc.New(ctx, otlploggrpc.WithGRPCConn(cfgs.OTLPExporterConn))
+	if err != nil {
+		cfgs.Logger.Error("failed to create OTLP log exporter", zap.Error(err))
+		return nil, err
+	}
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(provider.Resource(cfgs, "")),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+	)
+
+	otelCore := otelzap.NewCore(cfgs.AppConfigs.Name, otelzap.WithLoggerProvider(loggerProvider))
+	cfgs.Logger = cfgs.Logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, otelCore)
+	}))
+	cfgs.LoggerProvider = loggerProvider
+
+	return loggerProvider, nil
+}
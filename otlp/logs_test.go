@@ -0,0 +1,109 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// fakeLogsCollector is an OTLP logs collector keeping the exported records.
+type fakeLogsCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	// addr is the address the collector listens on
+	addr string
+
+	// mu guards records
+	mu sync.Mutex
+
+	// records are the exported log records
+	records []*logspb.LogRecord
+}
+
+// newFakeLogsCollector starts a logs collector on a local port until the test ends.
+func newFakeLogsCollector(t *testing.T) *fakeLogsCollector {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	c := &fakeLogsCollector{addr: lis.Addr().String()}
+
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, c)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return c
+}
+
+// Export keeps the exported records.
+func (c *fakeLogsCollector) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, resourceLogs := range req.GetResourceLogs() {
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			c.records = append(c.records, scopeLogs.GetLogRecords()...)
+		}
+	}
+
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func TestInstallLogsExportsZapEntriesWithTraceContext(t *testing.T) {
+	collector := newFakeLogsCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+
+	loggerProvider, err := InstallLogs(cfgs)
+	if err != nil {
+		t.Fatalf("InstallLogs: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = loggerProvider.Shutdown(context.Background())
+		_ = cfgs.OTLPExporterConn.Close()
+	})
+
+	if cfgs.LoggerProvider != loggerProvider {
+		t.Error("the logger provider is not stored in the configs")
+	}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "orders.create")
+	cfgs.Logger.Info("order created", zap.Any("context", ctx))
+	span.End()
+
+	if err := loggerProvider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	if len(collector.records) != 1 {
+		t.Fatalf("got %d exported records, want 1", len(collector.records))
+	}
+
+	record := collector.records[0]
+	if got := record.GetBody().GetStringValue(); got != "order created" {
+		t.Errorf("body = %q, want order created", got)
+	}
+
+	traceID := span.SpanContext().TraceID()
+	if !bytes.Equal(record.GetTraceId(), traceID[:]) {
+		t.Errorf("trace ID = %x, want the trace of the context %s", record.GetTraceId(), traceID)
+	}
+}
@@ -10,17 +10,40 @@ package otlp
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/goxkit/configs"
-	"github.com/goxkit/otel/otlpgrpc"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
+	"github.com/goxkit/tracing/exporter"
+	"github.com/goxkit/tracing/internal/provider"
+	"github.com/goxkit/tracing/options"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// TracesEndpointEnvKey is the environment variable setting a traces-specific OTLP endpoint,
+	// taking precedence over the endpoint shared by every signal
+	TracesEndpointEnvKey = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+)
+
+var (
+	// exporterConns holds the connection each tracer provider created by NewProvider exports
+	// through, until the provider shuts down
+	exporterConns sync.Map
 )
 
 // Install configures and initializes an OpenTelemetry tracer provider that exports
@@ -29,57 +52,487 @@ import (
 // environment attributes for better observability context.
 //
 // The function handles the complete setup of:
-// - OTLP exporter with gRPC transport
-// - Batch processing for efficient span export
+// - OTLP exporter with gRPC transport, export retry policy, optional compression and circuit breaker
+// - Connection setup bounded by a timeout, so an unreachable collector fails fast
+// - Recreation of a shared connection closed by a previous shutdown
+// - Optional logging and counting of the shared connection state changes
+// - Dedicated connection to the traces-specific endpoint set with OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// - Optional failover to a secondary collector when exports to the configured one fail
+// - Logging and counting of the spans rejected by the collector
+// - Batch processing for efficient span export with a configurable batch size
+// - Optional counters of exported and dropped spans and histogram of attributes per span
+// - Span limits for attributes, events and links
+// - Optional custom generator of trace and span IDs
+// - Redaction of sensitive span attributes, attribute allowlisting and truncation of oversized values
+// - Enrichment of spans from environment variables, host or pod names, baggage members and the tenant of the context
+// - Filtering of short spans and export of slow or failed spans dropped by the sampler
+// - User-supplied processing stages ahead of the built-in ones
+// - Configurable sampler, by default chosen by application environment, Jaeger remote sampling, per-operation sampling rules and debug sampling
 // - Resource attributes for service identification
-// - Global tracer provider registration
-// - W3C TraceContext propagation
+// - Global tracer provider registration, unless disabled with options.WithoutGlobalRegistration
+// - W3C TraceContext and Baggage propagation, also extracting B3 and Jaeger, configurable with OTEL_PROPAGATORS
+// - OpenTelemetry error reporting through the configured zap logger
+// - Optional logging of span events through the configured zap logger
+// - Optional warnings about span names embedding identifiers
+// - Optional ending of leaked spans still open after a maximum lifetime
 //
 // Parameters:
 //   - cfgs: Application configurations including OTLP endpoint and service information
+//   - opts: Installation options such as the export retry policy
 //
 // Returns:
 //   - *sdktrace.TracerProvider: The configured tracer provider with OTLP export capabilities
 //   - error: Any error encountered during setup
-func Install(cfgs *configs.Configs) (*sdktrace.TracerProvider, error) {
-	ctx := context.Background()
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := NewProvider(cfgs, opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	if cfgs.OTLPExporterConn == nil {
-		conn, err := otlpgrpc.NewExporterGRPCClient(cfgs)
-		if err != nil {
+	provider.Register(cfgs, options.New(opts...), tracerProvider)
+
+	return tracerProvider, nil
+}
+
+// NewProvider creates a tracer provider exporting via OTLP exactly like Install, without
+// registering it in the configs nor as the global tracer provider. Providers created this
+// way share the gRPC exporter connection of the configs, so several of them, e.g. one per
+// tenant with its own sampler, can export over a single connection.
+//
+// Parameters:
+//   - cfgs: Application configurations including OTLP endpoint and service information
+//   - opts: Installation options such as the sampler or resource attributes
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The tracer provider with OTLP export capabilities
+//   - error: Any error encountered during setup
+func NewProvider(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	o := options.New(opts...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.SetupTimeout)
+	defer cancel()
+
+	// With a failover endpoint, a failed export goes to the secondary collector right away
+	// instead of being retried against the primary one.
+	retry := o.Retry
+	if o.FailoverEndpoint != "" {
+		retry.Enabled = false
+	}
+
+	// gRPC compression is a property of the connection, so a compressed export
+	// cannot reuse the shared connection and dials its own instead, as does an
+	// export to a traces-specific endpoint.
+	useSharedConn := o.Compression == options.NoCompression && os.Getenv(TracesEndpointEnvKey) == ""
+
+	if useSharedConn && isClosed(cfgs.OTLPExporterConn) {
+		cfgs.Logger.Warn("grpc exporter connection is closed, recreating it")
+		cfgs.OTLPExporterConn = nil
+	}
+
+	if useSharedConn && cfgs.OTLPExporterConn == nil {
+		conn, err := dialExporter(ctx, cfgs, o)
+		switch {
+		case err == nil:
+			cfgs.OTLPExporterConn = conn
+		case o.LazyConnect:
+			cfgs.Logger.Warn("failed to create grpc exporter, connecting in background", zap.Error(err))
+		default:
 			cfgs.Logger.Error("failed to create grpc exporter", zap.Error(err))
 			return nil, err
 		}
-		cfgs.OTLPExporterConn = conn
 	}
 
-	exp, err := otlptracegrpc.New(
-		ctx,
-		otlptracegrpc.WithGRPCConn(cfgs.OTLPExporterConn),
-	)
+	if useSharedConn && cfgs.OTLPExporterConn != nil && o.ConnectionMetricsProvider != nil {
+		if err := monitorConnection(cfgs.OTLPExporterConn, cfgs.Logger, o.ConnectionMetricsProvider); err != nil {
+			cfgs.Logger.Error("failed to monitor grpc exporter connection", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// Without the shared connection, the exporter dials its own, established in the
+	// background and re-established until the collector becomes reachable.
+	conn, ownsConn := cfgs.OTLPExporterConn, false
+	if !useSharedConn || conn == nil {
+		var err error
+		conn, err = newConn(cfgs, o, tracesEndpoint(cfgs))
+		if err != nil {
+			cfgs.Logger.Error("failed to configure OTLP exporter connection", zap.Error(err))
+			return nil, err
+		}
+		ownsConn = true
+	}
+
+	exp, err := newExporter(ctx, conn, ownsConn, retry)
 	if err != nil {
 		cfgs.Logger.Error("failed to create OTLP trace exporter", zap.Error(err))
 		return nil, err
 	}
 
-	bsp := sdktrace.NewBatchSpanProcessor(exp)
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(cfgs.AppConfigs.Name),
-			semconv.ServiceNamespaceKey.String(cfgs.AppConfigs.Namespace),
-			attribute.String("service.environment", cfgs.AppConfigs.Environment.String()),
-			semconv.DeploymentEnvironmentKey.String(cfgs.AppConfigs.Environment.String()),
-			semconv.TelemetrySDKLanguageKey.String("go"),
-			semconv.TelemetrySDKLanguageGo.Key.Bool(true),
-		)),
-		sdktrace.WithSpanProcessor(bsp),
-	)
-
-	cfgs.TracerProvider = tracerProvider
-	otel.SetTracerProvider(tracerProvider)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	var spanExporter sdktrace.SpanExporter = exp
+	if o.FailoverEndpoint != "" {
+		spanExporter, err = newFailover(ctx, cfgs, o, exp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tracerProvider, err := provider.New(cfgs, o, spanExporter)
+	if err != nil {
+		return nil, err
+	}
+
+	exporterConns.Store(tracerProvider, conn)
+	exp.onShutdown = func() {
+		exporterConns.Delete(tracerProvider)
+	}
 
 	return tracerProvider, nil
 }
+
+// ExporterConn returns the gRPC connection a tracer provider created by this package exports
+// through: the shared connection of the configs, or the connection dialed for a
+// traces-specific endpoint or compression. With a failover endpoint, it is the connection
+// to the configured collector.
+//
+// Parameters:
+//   - tracerProvider: The tracer provider returned by Install or NewProvider
+//
+// Returns:
+//   - *grpc.ClientConn: The exporter connection
+//   - bool: Whether the provider was created by this package and is not shut down
+func ExporterConn(tracerProvider *sdktrace.TracerProvider) (*grpc.ClientConn, bool) {
+	conn, ok := exporterConns.Load(tracerProvider)
+	if !ok {
+		return nil, false
+	}
+
+	return conn.(*grpc.ClientConn), true
+}
+
+// newExporter creates an OTLP trace exporter sending the spans over the connection.
+//
+// Parameters:
+//   - ctx: The context bounding the exporter setup
+//   - conn: The connection the spans are exported through
+//   - ownsConn: Whether the connection was dialed for the exporter, closing it on shutdown
+//   - retry: The export retry policy
+//
+// Returns:
+//   - *connExporter: The exporter
+//   - error: Any error encountered during setup
+func newExporter(ctx context.Context, conn *grpc.ClientConn, ownsConn bool, retry options.RetryConfig) (*connExporter, error) {
+	exp, err := otlptracegrpc.New(ctx, retryOption(retry), otlptracegrpc.WithGRPCConn(conn))
+	if err != nil {
+		if ownsConn {
+			_ = conn.Close()
+		}
+		return nil, err
+	}
+
+	return &connExporter{SpanExporter: exp, conn: conn, ownsConn: ownsConn}, nil
+}
+
+// newFailover creates the exporter of the failover endpoint, with its own connection, and
+// combines it with the primary exporter.
+//
+// Parameters:
+//   - ctx: The context bounding the exporter setup
+//   - cfgs: Application configurations including the OTLP endpoint settings
+//   - o: The resolved installation options, including the failover endpoint
+//   - primary: The exporter of the configured endpoint
+//
+// Returns:
+//   - sdktrace.SpanExporter: The failover exporter
+//   - error: Any error encountered during setup
+func newFailover(ctx context.Context, cfgs *configs.Configs, o *options.Config, primary sdktrace.SpanExporter) (sdktrace.SpanExporter, error) {
+	conn, err := newConn(cfgs, o, o.FailoverEndpoint)
+	if err != nil {
+		cfgs.Logger.Error("failed to configure OTLP failover exporter connection", zap.Error(err))
+		return nil, err
+	}
+
+	secondary, err := newExporter(ctx, conn, true, o.Retry)
+	if err != nil {
+		cfgs.Logger.Error("failed to create OTLP failover trace exporter", zap.Error(err))
+		return nil, err
+	}
+
+	failover, err := exporter.NewFailover(primary, secondary, cfgs.OTLPConfigs.ExporterTimeout, o.ExportMetricsProvider)
+	if err != nil {
+		cfgs.Logger.Error("failed to create failover exporter", zap.Error(err))
+		return nil, err
+	}
+
+	return failover, nil
+}
+
+// dialExporter creates the shared gRPC exporter connection and waits until it is ready,
+// giving up when the context is done. Creating the connection does not contact the
+// collector, so the wait is what bounds the setup against an unreachable collector.
+//
+// Parameters:
+//   - ctx: The context bounding the connection setup
+//   - cfgs: Application configurations including the OTLP endpoint settings
+//   - o: The resolved installation options, including the export metrics provider
+//
+// Returns:
+//   - *grpc.ClientConn: The ready exporter connection
+//   - error: Any error encountered while connecting, or the context error on timeout
+func dialExporter(ctx context.Context, cfgs *configs.Configs, o *options.Config) (*grpc.ClientConn, error) {
+	conn, err := newConn(cfgs, o, cfgs.OTLPConfigs.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := waitForReady(ctx, conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("connecting to the OTLP collector %s: %w", conn.Target(), err)
+	}
+
+	return conn, nil
+}
+
+// waitForReady starts connecting and blocks until the connection is ready or the context
+// is done. Failed attempts are retried by gRPC with backoff in the meantime.
+//
+// Parameters:
+//   - ctx: The context bounding the wait
+//   - conn: The connection to wait for
+//
+// Returns:
+//   - error: The context error when the connection is not ready in time
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
+
+// isClosed reports whether the shared exporter connection has been closed, e.g. by a previous
+// shutdown, in which case exports through it would silently fail and it must be recreated.
+//
+// Parameters:
+//   - conn: The shared exporter connection, may be nil
+//
+// Returns:
+//   - bool: Whether the connection is closed
+func isClosed(conn *grpc.ClientConn) bool {
+	return conn != nil && conn.GetState() == connectivity.Shutdown
+}
+
+// retryOption builds the exporter option applying an export retry policy.
+//
+// Parameters:
+//   - retry: The export retry policy
+//
+// Returns:
+//   - otlptracegrpc.Option: The retry option
+func retryOption(retry options.RetryConfig) otlptracegrpc.Option {
+	return otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+		Enabled:         retry.Enabled,
+		InitialInterval: retry.InitialInterval,
+		MaxInterval:     retry.MaxInterval,
+		MaxElapsedTime:  retry.MaxElapsedTime,
+	})
+}
+
+// tracesEndpoint returns the traces-specific endpoint when one is set, the configured
+// endpoint otherwise.
+//
+// Parameters:
+//   - cfgs: Application configurations including the OTLP endpoint settings
+//
+// Returns:
+//   - string: The endpoint receiving the spans
+func tracesEndpoint(cfgs *configs.Configs) string {
+	if endpoint := os.Getenv(TracesEndpointEnvKey); endpoint != "" {
+		return endpoint
+	}
+
+	return cfgs.OTLPConfigs.Endpoint
+}
+
+// newConn creates a gRPC connection to an OTLP endpoint, with the idle timeout, keepalive
+// and reconnection backoff of the goxkit/otel connections. Creating it does not contact the
+// collector: the connection is established in the background on first use and
+// re-established until the collector becomes reachable. It targets the host:port and uses
+// the transport security derived from the endpoint by parseEndpoint, so URL endpoints work
+// like bare host:port ones. Its calls carry the exporter headers and use the configured
+// compression, and its export responses are inspected for spans rejected by the collector.
+//
+// Parameters:
+//   - cfgs: Application configurations including the OTLP TLS, keepalive and header settings
+//   - o: The resolved installation options, including the compression and export metrics provider
+//   - endpoint: The endpoint the connection targets
+//
+// Returns:
+//   - *grpc.ClientConn: The connection
+//   - error: Any error encountered while parsing the endpoint or creating the instruments
+func newConn(cfgs *configs.Configs, o *options.Config, endpoint string) (*grpc.ClientConn, error) {
+	target, secure, err := parseEndpoint(endpoint, cfgs.OTLPConfigs.ExporterTLSEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	interceptor, err := partialSuccessInterceptor(cfgs.Logger, o.ExportMetricsProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithIdleTimeout(cfgs.OTLPConfigs.ExporterIdleTimeout),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfgs.OTLPConfigs.ExporterKeepAliveTime,
+			Timeout: cfgs.OTLPConfigs.ExporterKeepAliveTimeout,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  time.Second,
+				Multiplier: 1.6,
+				MaxDelay:   15 * time.Second,
+			},
+			MinConnectTimeout: cfgs.OTLPConfigs.ExporterReconnectionPeriod,
+		}),
+		grpc.WithUnaryInterceptor(interceptor),
+	}
+
+	if secure {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if headers := parseHeaders(cfgs.OTLPConfigs.ExporterHeaders); len(headers) > 0 {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(headerCredentials{headers: headers, secure: secure}))
+	}
+
+	// Compression is applied to every call of the connection, as the exporter only
+	// compresses the calls of a connection it dials itself.
+	if o.Compression != options.NoCompression {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(o.Compression)))
+	}
+
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otel exporter gRPC conn: %w", err)
+	}
+
+	return conn, nil
+}
+
+// parseEndpoint derives the gRPC target and transport security from the configured endpoint,
+// following the OpenTelemetry specification: a URL with the https scheme uses TLS, one with
+// the http scheme is insecure, and a bare host:port relies on the TLS setting. The URL path
+// is ignored, as gRPC exports do not use it.
+//
+// Parameters:
+//   - endpoint: The endpoint, e.g. https://otlp.example.com:4317 or otel-collector:4317
+//   - tlsEnabled: The transport security of a bare host:port endpoint
+//
+// Returns:
+//   - string: The host:port target
+//   - bool: Whether the connection uses TLS
+//   - error: An error describing a malformed endpoint
+func parseEndpoint(endpoint string, tlsEnabled bool) (string, bool, error) {
+	if !strings.Contains(endpoint, "://") {
+		return endpoint, tlsEnabled, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Host == "" {
+		return "", false, fmt.Errorf("invalid OTLP endpoint %q: missing host", endpoint)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		return u.Host, true, nil
+	case "http":
+		return u.Host, false, nil
+	default:
+		return "", false, fmt.Errorf("invalid OTLP endpoint %q: unsupported scheme %q", endpoint, u.Scheme)
+	}
+}
+
+// parseHeaders parses exporter headers in the "key1=value1,key2=value2" format.
+//
+// Parameters:
+//   - raw: The raw headers string
+//
+// Returns:
+//   - map[string]string: The parsed headers
+func parseHeaders(raw string) map[string]string {
+	headers := map[string]string{}
+
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return headers
+}
+
+// connExporter is a span exporter sending the spans over a gRPC connection, which it closes
+// on shutdown when the connection was dialed for it.
+type connExporter struct {
+	sdktrace.SpanExporter
+
+	// conn is the connection the spans are exported through
+	conn *grpc.ClientConn
+
+	// ownsConn tells whether the connection was dialed for the exporter rather than shared
+	ownsConn bool
+
+	// onShutdown is called once the exporter is shut down, if set
+	onShutdown func()
+}
+
+// Shutdown shuts the exporter down and closes the connection dialed for it.
+func (e *connExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+
+	if e.onShutdown != nil {
+		e.onShutdown()
+	}
+
+	if e.ownsConn {
+		err = errors.Join(err, e.conn.Close())
+	}
+
+	return err
+}
+
+// headerCredentials attaches the exporter headers to every call of a connection.
+type headerCredentials struct {
+	// headers are the metadata attached to every call
+	headers map[string]string
+
+	// secure tells whether the connection uses TLS
+	secure bool
+}
+
+// GetRequestMetadata returns the exporter headers.
+func (c headerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return c.headers, nil
+}
+
+// RequireTransportSecurity reports whether the headers are only sent over TLS, which is the
+// case for a connection using TLS.
+func (c headerCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
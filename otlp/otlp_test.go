@@ -0,0 +1,578 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCollector is an OTLP trace collector answering the exports with scripted errors.
+type fakeCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	// addr is the address the collector listens on
+	addr string
+
+	// mu guards the fields below
+	mu sync.Mutex
+
+	// errs are returned by the next exports, in order, before they succeed
+	errs []error
+
+	// response is returned by the successful exports, an empty response when nil
+	response *coltracepb.ExportTraceServiceResponse
+
+	// exports counts the export calls
+	exports int
+
+	// metadata is the metadata of the last export
+	metadata metadata.MD
+
+	// compression is the compression of the last export request
+	compression string
+}
+
+// newFakeCollector starts a collector on a local port until the test ends.
+func newFakeCollector(t *testing.T, errs ...error) *fakeCollector {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	c := &fakeCollector{addr: lis.Addr().String(), errs: errs}
+
+	server := grpc.NewServer(grpc.StatsHandler(c))
+	coltracepb.RegisterTraceServiceServer(server, c)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return c
+}
+
+// Export records the call and returns the next scripted error, or the response.
+func (c *fakeCollector) Export(ctx context.Context, _ *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.exports++
+	c.metadata, _ = metadata.FromIncomingContext(ctx)
+
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+
+	if c.response != nil {
+		return c.response, nil
+	}
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// TagRPC returns the context unchanged.
+func (c *fakeCollector) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC records the compression of the export requests.
+func (c *fakeCollector) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		c.mu.Lock()
+		c.compression = header.Compression
+		c.mu.Unlock()
+	}
+}
+
+// TagConn returns the context unchanged.
+func (c *fakeCollector) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn ignores the connection events.
+func (c *fakeCollector) HandleConn(context.Context, stats.ConnStats) {}
+
+// calls returns the number of export calls.
+func (c *fakeCollector) calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.exports
+}
+
+// newTestConfigs returns the configs of a service exporting to the given endpoint.
+func newTestConfigs(endpoint string) *configs.Configs {
+	return &configs.Configs{
+		Logger: zap.NewNop(),
+		AppConfigs: &configs.AppConfigs{
+			Name:        "orders",
+			Environment: configs.LocalEnv,
+		},
+		OTLPConfigs: &configs.OTLPConfigs{Endpoint: endpoint},
+	}
+}
+
+// newBlackhole returns the address of a listener that never answers, like a collector
+// dropping every packet: connections are queued by the kernel but never accepted.
+func newBlackhole(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+
+	return lis.Addr().String()
+}
+
+// install installs a tracer provider exporting to the configs endpoint, shut down and
+// replaced by the previous global state when the test ends.
+func install(t *testing.T, cfgs *configs.Configs, opts ...options.Option) *sdktrace.TracerProvider {
+	t.Helper()
+
+	tracerProvider := otel.GetTracerProvider()
+	textMapPropagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(textMapPropagator)
+	})
+
+	tp, err := Install(cfgs, opts...)
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp
+}
+
+// exportSpan ends a span of the tracer provider and flushes it to the collector.
+func exportSpan(tp *sdktrace.TracerProvider) error {
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	return tp.ForceFlush(context.Background())
+}
+
+func TestInstallRetriesTransientErrors(t *testing.T) {
+	collector := newFakeCollector(t,
+		status.Error(codes.Unavailable, "starting"),
+		status.Error(codes.Unavailable, "starting"),
+	)
+	tp := install(t, newTestConfigs(collector.addr), options.WithRetry(time.Millisecond, time.Millisecond, time.Second))
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if got := collector.calls(); got != 3 {
+		t.Errorf("got %d exports, want 3", got)
+	}
+}
+
+func TestInstallDoesNotRetryPermanentErrors(t *testing.T) {
+	collector := newFakeCollector(t, status.Error(codes.InvalidArgument, "malformed"))
+	tp := install(t, newTestConfigs(collector.addr), options.WithRetry(time.Millisecond, time.Millisecond, time.Second))
+
+	if err := exportSpan(tp); err == nil {
+		t.Fatal("export succeeded, want the InvalidArgument error")
+	}
+
+	if got := collector.calls(); got != 1 {
+		t.Errorf("got %d exports, want 1", got)
+	}
+}
+
+func TestInstallWithoutRetry(t *testing.T) {
+	collector := newFakeCollector(t, status.Error(codes.Unavailable, "starting"))
+	tp := install(t, newTestConfigs(collector.addr), options.WithoutRetry())
+
+	if err := exportSpan(tp); err == nil {
+		t.Fatal("export succeeded, want the Unavailable error")
+	}
+
+	if got := collector.calls(); got != 1 {
+		t.Errorf("got %d exports, want 1", got)
+	}
+}
+
+func TestInstallGivesUpAfterMaxElapsedTime(t *testing.T) {
+	errs := make([]error, 100)
+	for i := range errs {
+		errs[i] = status.Error(codes.Unavailable, "down")
+	}
+
+	collector := newFakeCollector(t, errs...)
+	tp := install(t, newTestConfigs(collector.addr), options.WithRetry(10*time.Millisecond, 10*time.Millisecond, 35*time.Millisecond))
+
+	if err := exportSpan(tp); err == nil {
+		t.Fatal("export succeeded, want the Unavailable error")
+	}
+
+	if got := collector.calls(); got < 2 || got > 6 {
+		t.Errorf("got %d exports, want the attempts of about 35ms", got)
+	}
+}
+
+func TestInstallDialsItsOwnConnectionWhenCompressing(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+	tp := install(t, cfgs, options.WithCompression(options.GzipCompression))
+
+	if cfgs.OTLPExporterConn != nil {
+		t.Error("a compressed export created the shared connection")
+	}
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	if collector.compression != "gzip" {
+		t.Errorf("compression = %q, want gzip", collector.compression)
+	}
+}
+
+func TestInstallAppliesSpanLimits(t *testing.T) {
+	collector := newFakeCollector(t)
+
+	limits := sdktrace.NewSpanLimits()
+	limits.AttributeCountLimit = 2
+	limits.AttributeValueLengthLimit = 4
+	limits.EventCountLimit = 1
+
+	tp := install(t, newTestConfigs(collector.addr), options.WithSpanLimits(limits))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.SetAttributes(
+		attribute.String("a", "truncated"),
+		attribute.Int("b", 2),
+		attribute.Int("c", 3),
+	)
+	span.AddEvent("first")
+	span.AddEvent("second")
+	span.End()
+
+	ended := span.(sdktrace.ReadOnlySpan)
+	if len(ended.Attributes()) != 2 || ended.DroppedAttributes() != 1 {
+		t.Errorf("got %d attributes and %d dropped, want 2 and 1", len(ended.Attributes()), ended.DroppedAttributes())
+	}
+
+	if got := ended.Attributes()[0].Value.AsString(); got != "trun" {
+		t.Errorf("a = %q, want the value truncated to trun", got)
+	}
+
+	if len(ended.Events()) != 1 || ended.DroppedEvents() != 1 {
+		t.Errorf("got %d events and %d dropped, want 1 and 1", len(ended.Events()), ended.DroppedEvents())
+	}
+}
+
+func TestInstallUsesSDKSpanLimitsByDefault(t *testing.T) {
+	if got, want := options.New().SpanLimits, sdktrace.NewSpanLimits(); got != want {
+		t.Errorf("default span limits = %+v, want the SDK defaults %+v", got, want)
+	}
+}
+
+func TestInstallFailsFastWithABlackholeCollector(t *testing.T) {
+	cfgs := newTestConfigs(newBlackhole(t))
+
+	started := time.Now()
+	_, err := Install(cfgs, options.WithSetupTimeout(500*time.Millisecond))
+	elapsed := time.Since(started)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Install error = %v, want the setup deadline", err)
+	}
+
+	if elapsed < 500*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("Install returned after %s, want it to give up at the 500ms setup timeout", elapsed)
+	}
+
+	if cfgs.OTLPExporterConn != nil || cfgs.TracerProvider != nil {
+		t.Error("a failed installation was stored in the configs")
+	}
+}
+
+func TestInstallWaitsForTheConnection(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+	install(t, cfgs, options.WithSetupTimeout(5*time.Second))
+
+	if cfgs.OTLPExporterConn == nil || cfgs.OTLPExporterConn.GetState() != connectivity.Ready {
+		t.Fatal("Install returned before the shared connection was ready")
+	}
+}
+
+func TestInstallConnectsLazily(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+	tp := install(t, cfgs, options.WithSetupTimeout(0), options.WithLazyConnect())
+
+	if cfgs.OTLPExporterConn != nil {
+		t.Error("the shared connection was stored although its setup failed")
+	}
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if got := collector.calls(); got != 1 {
+		t.Errorf("got %d exports, want 1", got)
+	}
+}
+
+func TestNewProviderExportsToTheTracesEndpoint(t *testing.T) {
+	shared, traces := newFakeCollector(t), newFakeCollector(t)
+	t.Setenv(TracesEndpointEnvKey, "http://"+traces.addr)
+
+	cfgs := newTestConfigs(shared.addr)
+
+	tp, err := NewProvider(cfgs)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	if cfgs.OTLPExporterConn != nil {
+		t.Error("an export to the traces endpoint created the shared connection")
+	}
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if traces.calls() != 1 || shared.calls() != 0 {
+		t.Errorf("got %d exports to the traces endpoint and %d to the shared one, want 1 and 0", traces.calls(), shared.calls())
+	}
+}
+
+func TestNewProviderFailsOverToTheSecondaryCollector(t *testing.T) {
+	primary := newFakeCollector(t, status.Error(codes.Unavailable, "shutting down"))
+	secondary := newFakeCollector(t)
+
+	tp, err := NewProvider(newTestConfigs(primary.addr), options.WithSyncExport(), options.WithFailoverEndpoint("http://"+secondary.addr))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if primary.calls() != 1 || secondary.calls() != 1 {
+		t.Errorf("got %d exports to the primary and %d to the secondary, want a single attempt on each", primary.calls(), secondary.calls())
+	}
+}
+
+func TestExporterConnOfADedicatedConnection(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+
+	tp, err := NewProvider(cfgs, options.WithCompression(options.GzipCompression))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	conn, ok := ExporterConn(tp)
+	if !ok || conn == nil || conn == cfgs.OTLPExporterConn {
+		t.Fatalf("ExporterConn = %v, %t, want the connection dialed for the provider", conn, ok)
+	}
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if conn.GetState() != connectivity.Shutdown {
+		t.Errorf("connection state after shutdown = %s, want it closed", conn.GetState())
+	}
+
+	if _, ok := ExporterConn(tp); ok {
+		t.Error("ExporterConn reports a shut down provider")
+	}
+}
+
+func TestTracesEndpoint(t *testing.T) {
+	cfgs := newTestConfigs("collector:4317")
+
+	if got := tracesEndpoint(cfgs); got != "collector:4317" {
+		t.Errorf("tracesEndpoint without %s = %s, want the configured endpoint", TracesEndpointEnvKey, got)
+	}
+
+	t.Setenv(TracesEndpointEnvKey, "https://traces-collector:4317")
+
+	if got := tracesEndpoint(cfgs); got != "https://traces-collector:4317" {
+		t.Errorf("tracesEndpoint = %s, want the traces endpoint", got)
+	}
+}
+
+func TestInstallWithoutGlobalRegistrationKeepsTheGlobalProvider(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+
+	global := otel.GetTracerProvider()
+	propagator := otel.GetTextMapPropagator()
+
+	tp, err := Install(cfgs, options.WithoutGlobalRegistration())
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	if otel.GetTracerProvider() != global || otel.GetTextMapPropagator() != propagator {
+		t.Error("Install replaced the global tracer provider or propagator")
+	}
+
+	if cfgs.TracerProvider != tp {
+		t.Error("the tracer provider is not stored in the configs")
+	}
+}
+
+func TestInstallSharesAConnectionToAURLEndpoint(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs("http://" + collector.addr + "/v1/traces")
+	tp := install(t, cfgs)
+
+	if cfgs.OTLPExporterConn == nil || cfgs.OTLPExporterConn.Target() != collector.addr {
+		t.Fatalf("shared connection = %v, want a connection to %s", cfgs.OTLPExporterConn, collector.addr)
+	}
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if got := collector.calls(); got != 1 {
+		t.Errorf("got %d exports, want 1", got)
+	}
+}
+
+func TestInstallSendsTheExporterHeadersOverTheSharedConnection(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+	cfgs.OTLPConfigs.ExporterHeaders = "x-tenant=acme"
+	tp := install(t, cfgs)
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	if got := collector.metadata.Get("x-tenant"); len(got) != 1 || got[0] != "acme" {
+		t.Errorf("x-tenant = %v, want acme", got)
+	}
+}
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint   string
+		tlsEnabled bool
+		target     string
+		secure     bool
+	}{
+		{endpoint: "http://otel-collector:4317", tlsEnabled: true, target: "otel-collector:4317", secure: false},
+		{endpoint: "https://otlp.example.com:4317", target: "otlp.example.com:4317", secure: true},
+		{endpoint: "HTTPS://otlp.example.com:4317/v1/traces", target: "otlp.example.com:4317", secure: true},
+		{endpoint: "otel-collector:4317", target: "otel-collector:4317", secure: false},
+		{endpoint: "otel-collector:4317", tlsEnabled: true, target: "otel-collector:4317", secure: true},
+	}
+
+	for _, tt := range tests {
+		target, secure, err := parseEndpoint(tt.endpoint, tt.tlsEnabled)
+		if err != nil {
+			t.Fatalf("parseEndpoint(%s): %v", tt.endpoint, err)
+		}
+
+		if target != tt.target || secure != tt.secure {
+			t.Errorf("parseEndpoint(%s, %t) = %s, %t, want %s, %t", tt.endpoint, tt.tlsEnabled, target, secure, tt.target, tt.secure)
+		}
+	}
+}
+
+func TestParseEndpointRejectsMalformedURLs(t *testing.T) {
+	for _, endpoint := range []string{"grpc://otel-collector:4317", "http://", "http://otel collector:4317"} {
+		if _, _, err := parseEndpoint(endpoint, false); err == nil {
+			t.Errorf("parseEndpoint(%s) succeeded, want an error", endpoint)
+		}
+	}
+}
+
+func TestNewProviderRecreatesAClosedConnection(t *testing.T) {
+	collector := newFakeCollector(t)
+	cfgs := newTestConfigs(collector.addr)
+
+	closed, err := grpc.NewClient(collector.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("creating the connection: %v", err)
+	}
+	_ = closed.Close()
+	cfgs.OTLPExporterConn = closed
+
+	tp, err := NewProvider(cfgs)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		_ = cfgs.OTLPExporterConn.Close()
+	})
+
+	if cfgs.OTLPExporterConn == closed || isClosed(cfgs.OTLPExporterConn) {
+		t.Fatal("the closed connection was reused")
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	if got := collector.calls(); got != 1 {
+		t.Errorf("got %d exports, want 1 through the recreated connection", got)
+	}
+}
+
+func TestIsClosed(t *testing.T) {
+	conn, err := grpc.NewClient("127.0.0.1:4317", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("creating the connection: %v", err)
+	}
+
+	if isClosed(nil) || isClosed(conn) {
+		t.Error("a missing or open connection is reported closed")
+	}
+
+	_ = conn.Close()
+
+	if !isClosed(conn) {
+		t.Error("a closed connection is not reported closed")
+	}
+}
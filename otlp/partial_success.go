@@ -0,0 +1,68 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// partialSuccessInterceptor creates a gRPC interceptor inspecting the responses of the trace
+// exports, which the exporter otherwise only reports as an OpenTelemetry error. When the
+// collector reports a partial success, e.g. spans rejected because of schema issues, the
+// rejected span count and the collector message are logged as a warning and the rejected
+// spans are counted by the otlp.exporter.rejected_spans counter, if a meter provider is given.
+//
+// Parameters:
+//   - logger: The logger writing the rejections
+//   - provider: The meter provider used to create the counter, nil to disable it
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: The interceptor to install on the exporter connection
+//   - error: Any error encountered while creating the counter
+func partialSuccessInterceptor(logger *zap.Logger, provider metric.MeterProvider) (grpc.UnaryClientInterceptor, error) {
+	var rejected metric.Int64Counter
+
+	if provider != nil {
+		counter, err := provider.Meter(meterName).Int64Counter(
+			"otlp.exporter.rejected_spans",
+			metric.WithDescription("Number of exported spans rejected by the OTLP collector"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		rejected = counter
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		resp, ok := reply.(*coltracepb.ExportTraceServiceResponse)
+		if err != nil || !ok {
+			return err
+		}
+
+		partial := resp.GetPartialSuccess()
+		if partial.GetRejectedSpans() == 0 && partial.GetErrorMessage() == "" {
+			return nil
+		}
+
+		logger.Warn("OTLP collector rejected spans",
+			zap.String("endpoint", cc.Target()),
+			zap.Int64("rejected_spans", partial.GetRejectedSpans()),
+			zap.String("reason", partial.GetErrorMessage()),
+		)
+
+		if rejected != nil {
+			rejected.Add(ctx, partial.GetRejectedSpans())
+		}
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goxkit/tracing/options"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newRejectingCollector starts a collector answering every export with a partial success
+// rejecting two spans.
+func newRejectingCollector(t *testing.T) *fakeCollector {
+	t.Helper()
+
+	collector := newFakeCollector(t)
+	collector.response = &coltracepb.ExportTraceServiceResponse{
+		PartialSuccess: &coltracepb.ExportTracePartialSuccess{RejectedSpans: 2, ErrorMessage: "invalid schema"},
+	}
+
+	return collector
+}
+
+// rejectedSpans returns the value of the otlp.exporter.rejected_spans counter.
+func rejectedSpans(t *testing.T, reader sdkmetric.Reader) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "otlp.exporter.rejected_spans" {
+				return data.DataPoints[0].Value
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestInstallLogsAndCountsRejectedSpansOverTheSharedConnection(t *testing.T) {
+	collector := newRejectingCollector(t)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	cfgs := newTestConfigs(collector.addr)
+	cfgs.Logger = zap.New(core)
+
+	reader := sdkmetric.NewManualReader()
+	tp := install(t, cfgs, options.WithExportMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	if cfgs.OTLPExporterConn == nil {
+		t.Fatal("the shared connection was not created")
+	}
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	warnings := logs.FilterMessage("OTLP collector rejected spans").All()
+	if len(warnings) != 1 {
+		t.Fatalf("got %d rejection warnings, want 1", len(warnings))
+	}
+
+	fields := warnings[0].ContextMap()
+	if fields["rejected_spans"] != int64(2) || fields["reason"] != "invalid schema" || fields["endpoint"] != collector.addr {
+		t.Errorf("fields = %v, want the rejected span count, reason and endpoint", fields)
+	}
+
+	if got := rejectedSpans(t, reader); got != 2 {
+		t.Errorf("otlp.exporter.rejected_spans = %d, want 2", got)
+	}
+}
+
+func TestNewProviderLogsRejectedSpansOverADedicatedConnection(t *testing.T) {
+	collector := newRejectingCollector(t)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	cfgs := newTestConfigs(collector.addr)
+	cfgs.Logger = zap.New(core)
+
+	tp, err := NewProvider(cfgs, options.WithCompression(options.GzipCompression))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if got := logs.FilterMessage("OTLP collector rejected spans").Len(); got != 1 {
+		t.Errorf("got %d rejection warnings, want 1", got)
+	}
+}
+
+func TestInstallIgnoresFullSuccesses(t *testing.T) {
+	collector := newFakeCollector(t)
+	collector.response = &coltracepb.ExportTraceServiceResponse{PartialSuccess: &coltracepb.ExportTracePartialSuccess{}}
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	cfgs := newTestConfigs(collector.addr)
+	cfgs.Logger = zap.New(core)
+
+	tp := install(t, cfgs)
+
+	if err := exportSpan(tp); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if got := logs.FilterMessage("OTLP collector rejected spans").Len(); got != 0 {
+		t.Errorf("got %d rejection warnings for a full success, want none", got)
+	}
+}
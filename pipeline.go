@@ -0,0 +1,118 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Carried is a work item carrying the trace context it was produced in, so the trace survives
+// its passage through a channel to another goroutine.
+type Carried[T any] struct {
+	// Payload is the work item
+	Payload T
+
+	// spanContext is the span context the item was produced in
+	spanContext trace.SpanContext
+
+	// baggage is the baggage of the context the item was produced in
+	baggage baggage.Baggage
+}
+
+// Carry wraps a work item with the span context and baggage of ctx, to send it through a
+// channel. Only the trace context is carried, not the cancellation nor the other values of
+// ctx, so an item may outlive the request that produced it.
+//
+// Example usage:
+//
+//	items <- tracing.Carry(ctx, order)
+//
+// Parameters:
+//   - ctx: The context the item is produced in
+//   - payload: The work item
+//
+// Returns:
+//   - Carried[T]: The item carrying the trace context
+func Carry[T any](ctx context.Context, payload T) Carried[T] {
+	return Carried[T]{
+		Payload:     payload,
+		spanContext: trace.SpanContextFromContext(ctx),
+		baggage:     baggage.FromContext(ctx),
+	}
+}
+
+// Uncarry unwraps a work item received from a channel, returning a new context holding the
+// trace context and baggage the item was produced in, along with the item itself. Spans
+// started from the returned context belong to the trace of the producer.
+//
+// Example usage:
+//
+//	for item := range items {
+//		ctx, order := tracing.Uncarry(item)
+//		process(ctx, order)
+//	}
+//
+// Parameters:
+//   - item: The received item
+//
+// Returns:
+//   - context.Context: A context holding the carried trace context and baggage
+//   - T: The work item
+func Uncarry[T any](item Carried[T]) (context.Context, T) {
+	ctx := baggage.ContextWithBaggage(context.Background(), item.baggage)
+
+	if item.spanContext.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, item.spanContext)
+	}
+
+	return ctx, item.Payload
+}
+
+// RunStage runs a pipeline stage on a received work item inside a new span, child of the span
+// the item was produced in, so every stage of a fan-out/fan-in pipeline belongs to the trace
+// of the producer. The stage span context is passed to fn, which should Carry its outputs
+// with it so the next stage continues from this one. An error returned by fn is recorded on
+// the span with an error status, and the span is always ended.
+//
+// Example usage:
+//
+//	for item := range orders {
+//		_ = tracing.RunStage(item, "pipeline.enrich", func(ctx context.Context, order Order) error {
+//			enriched, err := enrich(ctx, order)
+//			if err != nil {
+//				return err
+//			}
+//			out <- tracing.Carry(ctx, enriched)
+//			return nil
+//		})
+//	}
+//
+// Parameters:
+//   - item: The received item
+//   - name: The stage span name
+//   - fn: The stage function
+//
+// Returns:
+//   - error: The error returned by fn
+func RunStage[T any](item Carried[T], name string, fn func(ctx context.Context, payload T) error) error {
+	ctx, payload := Uncarry(item)
+
+	ctx, span := Tracer("").Start(ctx, name)
+	defer span.End()
+
+	if err := fn(ctx, payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRunStageContinuesTheTraceAcrossChannels(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	member, _ := baggage.NewMember("tenant.id", "acme")
+	bag, _ := baggage.New(member)
+
+	ctx, producer := Tracer("").Start(baggage.ContextWithBaggage(context.Background(), bag), "orders.produce")
+
+	parsed := make(chan Carried[string])
+	enriched := make(chan Carried[string])
+	tenants := make(chan string, 1)
+
+	go func() {
+		defer close(enriched)
+
+		for item := range parsed {
+			_ = RunStage(item, "orders.enrich", func(ctx context.Context, order string) error {
+				enriched <- Carry(ctx, strings.ToUpper(order))
+				return nil
+			})
+		}
+	}()
+
+	go func() {
+		defer close(tenants)
+
+		for item := range enriched {
+			_ = RunStage(item, "orders.store", func(ctx context.Context, _ string) error {
+				tenants <- baggage.FromContext(ctx).Member("tenant.id").Value()
+				return nil
+			})
+		}
+	}()
+
+	parsed <- Carry(ctx, "order-1")
+	close(parsed)
+
+	if got := <-tenants; got != "acme" {
+		t.Errorf("tenant.id baggage of the last stage = %q, want acme", got)
+	}
+
+	// tenants is closed once both stages are done and their spans ended
+	<-tenants
+
+	producer.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	enrich, store := spans["orders.enrich"], spans["orders.store"]
+	if enrich == nil || store == nil {
+		t.Fatalf("got spans %v, want a span per stage", spans)
+	}
+
+	if enrich.Parent().SpanID() != producer.SpanContext().SpanID() || store.Parent().SpanID() != enrich.SpanContext().SpanID() {
+		t.Error("the stage spans are not chained to the span of the previous stage")
+	}
+
+	if enrich.SpanContext().TraceID() != producer.SpanContext().TraceID() || store.SpanContext().TraceID() != producer.SpanContext().TraceID() {
+		t.Error("the stage spans do not share the trace of the producer")
+	}
+}
+
+func TestRunStageRecordsErrors(t *testing.T) {
+	recorder := installTestProvider(t)
+	errStage := errors.New("enrichment failed")
+
+	err := RunStage(Carry(context.Background(), "order-1"), "orders.enrich", func(context.Context, string) error {
+		return errStage
+	})
+	if !errors.Is(err, errStage) {
+		t.Fatalf("RunStage = %v, want %v", err, errStage)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != codes.Error {
+		t.Errorf("got %d ended spans, want one span with an error status", len(spans))
+	}
+}
+
+func TestUncarryWithoutTraceContext(t *testing.T) {
+	ctx, payload := Uncarry(Carry(context.Background(), 42))
+
+	if payload != 42 || trace.SpanContextFromContext(ctx).IsValid() {
+		t.Errorf("Uncarry = (%v, %d), want no span context and the payload", trace.SpanContextFromContext(ctx), payload)
+	}
+}
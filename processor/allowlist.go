@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// allowlistProcessor strips the attributes not on an allowlist before handing spans to the next processor.
+type allowlistProcessor struct {
+	// next is the processor receiving the filtered spans
+	next sdktrace.SpanProcessor
+
+	// keys is the set of attribute keys allowed through
+	keys map[attribute.Key]struct{}
+}
+
+// NewAllowlist creates a span processor that removes every attribute whose key is not in
+// the allowlist before passing ended spans to the next processor. It is the inverse of
+// NewRedacting: attributes added by new instrumentation are dropped until they are approved,
+// giving a safe-by-default posture for strict data governance. Resource attributes, and the
+// attributes of events and links, are left untouched.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewAllowlist(bsp, "http.request.method", "http.response.status_code")),
+//	)
+//
+// Parameters:
+//   - next: The processor receiving the filtered spans, typically the exporting processor
+//   - keys: The attribute keys allowed through
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The filtering processor
+func NewAllowlist(next sdktrace.SpanProcessor, keys ...string) sdktrace.SpanProcessor {
+	set := make(map[attribute.Key]struct{}, len(keys))
+
+	for _, key := range keys {
+		set[attribute.Key(key)] = struct{}{}
+	}
+
+	return &allowlistProcessor{next: next, keys: set}
+}
+
+// OnStart delegates to the next processor.
+func (p *allowlistProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd strips the attributes not on the allowlist and delegates to the next processor.
+func (p *allowlistProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := s.Attributes()
+	allowed := make([]attribute.KeyValue, 0, len(attrs))
+
+	for _, kv := range attrs {
+		if _, ok := p.keys[kv.Key]; ok {
+			allowed = append(allowed, kv)
+		}
+	}
+
+	if len(allowed) != len(attrs) {
+		s = withAttributes(s, allowed)
+	}
+
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the next processor.
+func (p *allowlistProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *allowlistProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
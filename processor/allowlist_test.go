@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAllowlistKeepsAllowedAttributesOnly(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewAllowlist(next, "http.request.method", "http.response.status_code")
+	})
+
+	_, span := tracer.Start(context.Background(), "GET /orders", trace.WithAttributes(
+		attribute.String("http.request.method", "GET"),
+		attribute.Int("http.response.status_code", 200),
+		attribute.String("user.email", "jane@example.com"),
+	))
+	span.AddEvent("cache.miss", trace.WithAttributes(attribute.String("cache.key", "orders")))
+	span.End()
+
+	ended := recorder.Ended()[0]
+
+	attrs := attributeMap(ended)
+	if len(attrs) != 2 || attrs["http.request.method"].AsString() != "GET" || attrs["http.response.status_code"].AsInt64() != 200 {
+		t.Errorf("attributes = %v, want the allowlisted ones only", attrs)
+	}
+
+	if events := ended.Events(); len(events) != 1 || len(events[0].Attributes) != 1 {
+		t.Error("the event attributes were filtered")
+	}
+}
+
+func TestAllowlistWithoutKeysDropsEveryAttribute(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewAllowlist(next)
+	})
+
+	_, span := tracer.Start(context.Background(), "GET /orders", trace.WithAttributes(attribute.String("user.email", "jane@example.com")))
+	span.End()
+
+	if attrs := recorder.Ended()[0].Attributes(); len(attrs) != 0 {
+		t.Errorf("attributes = %v, want none", attrs)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// attributeCountProcessor records the number of attributes of ended spans.
+type attributeCountProcessor struct {
+	// count records the number of attributes per span
+	count metric.Int64Histogram
+}
+
+// NewAttributeCount creates a span processor that records the number of attributes of every
+// ended span, including the ones dropped by the span limits, in the span.attribute.count
+// histogram keyed by the otel.scope.name attribute of the instrumentation that created the
+// span. A spike in the distribution points at runaway instrumentation before it inflates the
+// export volume or hits the attribute limit.
+//
+// Example usage:
+//
+//	countProcessor, err := processor.NewAttributeCount(otel.GetMeterProvider())
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(countProcessor))
+//
+// Parameters:
+//   - provider: The meter provider used to create the histogram
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The metrics processor
+//   - error: Any error encountered while creating the histogram
+func NewAttributeCount(provider metric.MeterProvider) (sdktrace.SpanProcessor, error) {
+	count, err := provider.Meter(meterName).Int64Histogram(
+		"span.attribute.count",
+		metric.WithDescription("Number of attributes set on ended spans"),
+		metric.WithUnit("{attribute}"),
+		metric.WithExplicitBucketBoundaries(0, 4, 8, 16, 32, 64, 128, 256),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attributeCountProcessor{count: count}, nil
+}
+
+// OnStart does nothing.
+func (p *attributeCountProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd records the number of attributes of the ended span.
+func (p *attributeCountProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.count.Record(
+		context.Background(),
+		int64(len(s.Attributes())+s.DroppedAttributes()),
+		metric.WithAttributes(attribute.String("otel.scope.name", s.InstrumentationScope().Name)),
+	)
+}
+
+// Shutdown does nothing.
+func (p *attributeCountProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *attributeCountProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAttributeCountRecordsTheAttributesOfEachSpan(t *testing.T) {
+	mp, reader := newTestMeterProvider()
+
+	countProcessor, err := NewAttributeCount(mp)
+	if err != nil {
+		t.Fatalf("NewAttributeCount: %v", err)
+	}
+
+	limits := sdktrace.NewSpanLimits()
+	limits.AttributeCountLimit = 3
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(countProcessor), sdktrace.WithRawSpanLimits(limits))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("orders").Start(context.Background(), "orders.create", trace.WithAttributes(
+		attribute.String("a", "1"),
+		attribute.String("b", "2"),
+		attribute.String("c", "3"),
+		attribute.String("d", "4"),
+		attribute.String("e", "5"),
+	))
+	span.End()
+
+	_, span = tp.Tracer("orders").Start(context.Background(), "orders.list")
+	span.End()
+
+	data, ok := collect(t, reader)["span.attribute.count"].(metricdata.Histogram[int64])
+	if !ok || len(data.DataPoints) != 1 {
+		t.Fatalf("span.attribute.count = %v, want a histogram with one data point", data)
+	}
+
+	point := data.DataPoints[0]
+	maxCount, _ := point.Max.Value()
+	if point.Count != 2 || point.Sum != 5 || maxCount != 5 {
+		t.Errorf("got %d recorded spans, sum %d, want 2 spans with 5 and 0 attributes", point.Count, point.Sum)
+	}
+
+	if scope, _ := point.Attributes.Value("otel.scope.name"); scope.AsString() != "orders" {
+		t.Errorf("otel.scope.name = %s, want orders", scope.AsString())
+	}
+}
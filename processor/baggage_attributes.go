@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// baggageAttributesProcessor sets allowlisted baggage members as attributes on every started span.
+type baggageAttributesProcessor struct {
+	// keys are the baggage member keys promoted to attributes
+	keys []string
+}
+
+// NewBaggageAttributes creates a span processor that, when a span starts, sets the baggage
+// members of the parent context whose keys are allowed as span attributes of the same key,
+// so propagated context such as the tenant becomes queryable span data. Members that are not
+// allowed are never promoted, as baggage may hold values received from other services.
+//
+// Example usage:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewBaggageAttributes("tenant.id", "user.id")),
+//	)
+//
+// Parameters:
+//   - keys: The baggage member keys promoted to attributes
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The enriching processor
+func NewBaggageAttributes(keys ...string) sdktrace.SpanProcessor {
+	return &baggageAttributesProcessor{keys: keys}
+}
+
+// OnStart sets the allowed baggage members of the parent context as span attributes.
+func (p *baggageAttributesProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	bag := baggage.FromContext(parent)
+	if bag.Len() == 0 {
+		return
+	}
+
+	for _, key := range p.keys {
+		if member := bag.Member(key); member.Key() != "" {
+			s.SetAttributes(attribute.String(key, member.Value()))
+		}
+	}
+}
+
+// OnEnd does nothing.
+func (p *baggageAttributesProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *baggageAttributesProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *baggageAttributesProcessor) ForceFlush(context.Context) error {
+	return nil
+}
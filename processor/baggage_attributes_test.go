@@ -0,0 +1,44 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBaggageAttributesPromotesAllowedMembers(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageAttributes("tenant.id", "user.id")),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	tenant, _ := baggage.NewMember("tenant.id", "acme")
+	token, _ := baggage.NewMember("session.token", "secret")
+	bag, _ := baggage.New(tenant, token)
+
+	_, span := tp.Tracer("test").Start(baggage.ContextWithBaggage(context.Background(), bag), "orders.create")
+	span.End()
+
+	_, span = tp.Tracer("test").Start(context.Background(), "orders.list")
+	span.End()
+
+	spans := recorder.Ended()
+
+	attrs := attributeMap(spans[0])
+	if len(attrs) != 1 || attrs["tenant.id"].AsString() != "acme" {
+		t.Errorf("attributes = %v, want tenant.id only", attrs)
+	}
+
+	if attrs := spans[1].Attributes(); len(attrs) != 0 {
+		t.Errorf("attributes of a span without baggage = %v, want none", attrs)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Stage builds a processor handing the spans it processes to the next processor, so
+// processors can be assembled into an ordered pipeline with Chain.
+type Stage func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor
+
+// Chain assembles the stages into a pipeline ending with the exporting processor. Stages
+// process spans in the given order: the first stage receives the spans first and the last
+// one hands them to the exporting processor.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	pipeline := processor.Chain(bsp,
+//		processor.Enrich(processor.NewEnvAttributes(map[string]string{"user.email": "OPERATOR_EMAIL"})),
+//		processor.Redact("user.email"),
+//		processor.Truncate(4096),
+//	)
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(pipeline))
+//
+// Parameters:
+//   - exporting: The processor ending the pipeline, typically the batch processor
+//   - stages: The stages, in processing order
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The first processor of the pipeline
+func Chain(exporting sdktrace.SpanProcessor, stages ...Stage) sdktrace.SpanProcessor {
+	p := exporting
+
+	for i := len(stages) - 1; i >= 0; i-- {
+		p = stages[i](p)
+	}
+
+	return p
+}
+
+// Redact creates a stage masking the given attribute keys, see NewRedacting.
+//
+// Parameters:
+//   - keys: The attribute keys to redact
+//
+// Returns:
+//   - Stage: The redacting stage
+func Redact(keys ...string) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewRedacting(next, keys...)
+	}
+}
+
+// Allow creates a stage removing the attributes not on the allowlist, see NewAllowlist.
+//
+// Parameters:
+//   - keys: The attribute keys allowed through
+//
+// Returns:
+//   - Stage: The filtering stage
+func Allow(keys ...string) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewAllowlist(next, keys...)
+	}
+}
+
+// Truncate creates a stage shortening oversized string attribute values, see NewTruncating.
+//
+// Parameters:
+//   - maxLength: The maximum length in bytes of string attribute values
+//
+// Returns:
+//   - Stage: The truncating stage
+func Truncate(maxLength int) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewTruncating(next, maxLength)
+	}
+}
+
+// DropShorterThan creates a stage dropping spans shorter than minDuration, see NewMinDuration.
+//
+// Parameters:
+//   - minDuration: The duration below which spans are dropped
+//
+// Returns:
+//   - Stage: The filtering stage
+func DropShorterThan(minDuration time.Duration) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewMinDuration(next, minDuration)
+	}
+}
+
+// KeepSlowerThan creates a stage exporting not sampled spans lasting at least threshold,
+// see NewLatencyKeep.
+//
+// Parameters:
+//   - threshold: The duration from which not sampled spans are exported
+//
+// Returns:
+//   - Stage: The latency keeping stage
+func KeepSlowerThan(threshold time.Duration) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewLatencyKeep(next, threshold)
+	}
+}
+
+// Keep creates a stage exporting not sampled spans matching any of the rules, see NewKeep.
+// Use a single stage for all the rules, as each stage discards the spans its rules do not keep.
+//
+// Parameters:
+//   - rules: The rules deciding which not sampled spans are exported
+//
+// Returns:
+//   - Stage: The keeping stage
+func Keep(rules ...KeepRule) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewKeep(next, rules...)
+	}
+}
+
+// Enrich creates a stage running a standalone processor, such as NewEnvAttributes or NewHost,
+// before the next processor: the processor sees every started span first, so the attributes it
+// sets are visible to the following stages.
+//
+// Parameters:
+//   - p: The processor to run
+//
+// Returns:
+//   - Stage: The enriching stage
+func Enrich(p sdktrace.SpanProcessor) Stage {
+	return func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return &enrichingProcessor{processor: p, next: next}
+	}
+}
+
+// enrichingProcessor runs a standalone processor before the next processor.
+type enrichingProcessor struct {
+	// processor is the standalone processor
+	processor sdktrace.SpanProcessor
+
+	// next is the processor receiving the spans afterwards
+	next sdktrace.SpanProcessor
+}
+
+// OnStart runs the standalone processor, then the next processor.
+func (p *enrichingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.processor.OnStart(parent, s)
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd runs the standalone processor, then the next processor.
+func (p *enrichingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.processor.OnEnd(s)
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the standalone processor and the next processor.
+func (p *enrichingProcessor) Shutdown(ctx context.Context) error {
+	return errors.Join(p.processor.Shutdown(ctx), p.next.Shutdown(ctx))
+}
+
+// ForceFlush flushes the standalone processor and the next processor.
+func (p *enrichingProcessor) ForceFlush(ctx context.Context) error {
+	return errors.Join(p.processor.ForceFlush(ctx), p.next.ForceFlush(ctx))
+}
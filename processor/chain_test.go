@@ -0,0 +1,64 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestChainRedactsEnrichedAttributes(t *testing.T) {
+	t.Setenv("OPERATOR_EMAIL", "ops@example.com")
+
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return Chain(next,
+			Enrich(NewEnvAttributes(map[string]string{"operator.email": "OPERATOR_EMAIL"})),
+			Redact("operator.email"),
+		)
+	})
+
+	_, span := tracer.Start(context.Background(), "orders.create")
+	span.End()
+
+	if got := attributeMap(recorder.Ended()[0])["operator.email"].AsString(); got != RedactedValue {
+		t.Errorf("operator.email = %q, want the enriched value redacted", got)
+	}
+}
+
+func TestChainRunsStagesInOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []Stage
+		want   string
+	}{
+		{name: "truncate then redact", stages: []Stage{Truncate(5), Redact("auth.token")}, want: RedactedValue},
+		{name: "redact then truncate", stages: []Stage{Redact("auth.token"), Truncate(5)}, want: "[R..."},
+	}
+
+	for _, tt := range tests {
+		tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+			return Chain(next, tt.stages...)
+		})
+
+		_, span := tracer.Start(context.Background(), "users.login", trace.WithAttributes(attribute.String("auth.token", "abc")))
+		span.End()
+
+		if got := attributeMap(recorder.Ended()[0])["auth.token"].AsString(); got != tt.want {
+			t.Errorf("%s: auth.token = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChainWithoutStagesReturnsTheExportingProcessor(t *testing.T) {
+	exporting := sdktrace.NewSimpleSpanProcessor(nil)
+
+	if Chain(exporting) != exporting {
+		t.Error("Chain wrapped the exporting processor without stages")
+	}
+}
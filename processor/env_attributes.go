@@ -0,0 +1,75 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// envAttributesProcessor sets attributes resolved from the environment on every started span.
+type envAttributesProcessor struct {
+	// attributes are the attributes set on every span
+	attributes []attribute.KeyValue
+}
+
+// NewEnvAttributes creates a span processor that attaches attributes read from environment
+// variables to every span when it starts, such as the Git commit SHA or build version of the
+// deployment. The variables are read once, when the processor is created, and unset or empty
+// variables are ignored. Unlike resource attributes, these attributes are recorded on the spans
+// themselves, which some backends require for filtering.
+//
+// Example usage:
+//
+//	p := processor.NewEnvAttributes(map[string]string{
+//		"vcs.commit.sha": "GIT_COMMIT_SHA",
+//		"build.version":  "BUILD_VERSION",
+//	})
+//
+// Parameters:
+//   - mapping: The environment variable name to read, indexed by attribute key
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The enriching processor
+func NewEnvAttributes(mapping map[string]string) sdktrace.SpanProcessor {
+	keys := make([]string, 0, len(mapping))
+
+	for k := range mapping {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		if value := os.Getenv(mapping[key]); value != "" {
+			attrs = append(attrs, attribute.String(key, value))
+		}
+	}
+
+	return &envAttributesProcessor{attributes: attrs}
+}
+
+// OnStart sets the environment attributes on the span.
+func (p *envAttributesProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	s.SetAttributes(p.attributes...)
+}
+
+// OnEnd does nothing.
+func (p *envAttributesProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *envAttributesProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *envAttributesProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnvAttributesSetsVariablesReadAtCreation(t *testing.T) {
+	t.Setenv("GIT_COMMIT_SHA", "abc123")
+	t.Setenv("BUILD_VERSION", "")
+
+	p := NewEnvAttributes(map[string]string{
+		"vcs.commit.sha": "GIT_COMMIT_SHA",
+		"build.version":  "BUILD_VERSION",
+		"deploy.region":  "UNSET_DEPLOY_REGION",
+	})
+
+	t.Setenv("GIT_COMMIT_SHA", "changed")
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p), sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	attrs := attributeMap(recorder.Ended()[0])
+	if len(attrs) != 1 {
+		t.Fatalf("attributes = %v, want vcs.commit.sha only", attrs)
+	}
+
+	if got := attrs["vcs.commit.sha"].AsString(); got != "abc123" {
+		t.Errorf("vcs.commit.sha = %q, want the value read at creation abc123", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// eventLogsProcessor writes the events of ended spans as log entries.
+type eventLogsProcessor struct {
+	// logger writes the log entries
+	logger *zap.Logger
+}
+
+// NewEventLogs creates a span processor that writes every event of the ended spans as an info
+// log entry through the given logger, for backends visualizing span events poorly. Each entry
+// is named after the event and carries its time and attributes, along with the trace_id,
+// span_id and span name, so it can be correlated with the trace.
+//
+// Example usage:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewEventLogs(cfgs.Logger)),
+//	)
+//
+// Parameters:
+//   - logger: The logger writing the events
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The event logging processor
+func NewEventLogs(logger *zap.Logger) sdktrace.SpanProcessor {
+	return &eventLogsProcessor{logger: logger}
+}
+
+// OnStart does nothing.
+func (p *eventLogsProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd logs the events of the span.
+func (p *eventLogsProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, event := range s.Events() {
+		fields := make([]zapcore.Field, 0, len(event.Attributes)+4)
+		fields = append(fields,
+			zap.String("trace_id", s.SpanContext().TraceID().String()),
+			zap.String("span_id", s.SpanContext().SpanID().String()),
+			zap.String("span_name", s.Name()),
+			zap.Time("event_time", event.Time),
+		)
+
+		for _, kv := range event.Attributes {
+			fields = append(fields, zap.Any(string(kv.Key), kv.Value.AsInterface()))
+		}
+
+		p.logger.Info(event.Name, fields...)
+	}
+}
+
+// Shutdown does nothing.
+func (p *eventLogsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *eventLogsProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestEventLogsLogsEachEventWithTheTraceContext(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewEventLogs(zap.New(core))))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.AddEvent("order.validated", trace.WithAttributes(attribute.Int("order.items", 3)))
+	span.AddEvent("order.stored")
+
+	if logs.Len() != 0 {
+		t.Fatal("events were logged before the span ended")
+	}
+
+	span.End()
+
+	entries := logs.All()
+	if len(entries) != 2 || entries[0].Message != "order.validated" || entries[1].Message != "order.stored" {
+		t.Fatalf("got %d log entries, want one per event in order", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	want := map[string]any{
+		"trace_id":    span.SpanContext().TraceID().String(),
+		"span_id":     span.SpanContext().SpanID().String(),
+		"span_name":   "orders.create",
+		"order.items": int64(3),
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("field %s = %v, want %v", key, fields[key], value)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/goxkit/tracing/exporter"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// maxQueueSizeEnvKey is the environment variable setting the queue size of batch processors
+	maxQueueSizeEnvKey = "OTEL_BSP_MAX_QUEUE_SIZE"
+)
+
+// exportCounters holds the instruments and the span accounting shared by the counting
+// processor and exporter.
+type exportCounters struct {
+	// exported counts the spans successfully exported
+	exported metric.Int64Counter
+
+	// dropped counts the spans never handed to the exporter
+	dropped metric.Int64Counter
+
+	// failures counts the failed export calls
+	failures metric.Int64Counter
+
+	// mu guards the accounting below
+	mu sync.Mutex
+
+	// pending is the number of spans handed to the batch processor and not yet handed to
+	// the exporter
+	pending int
+
+	// stopped is set once the batch processor has shut down
+	stopped bool
+}
+
+// countingExporter records the outcome of the exports of the wrapped exporter.
+type countingExporter struct {
+	sdktrace.SpanExporter
+
+	// counters are the shared counters
+	counters *exportCounters
+}
+
+// ExportSpans exports the spans and records the outcome.
+func (e *countingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.counters.mu.Lock()
+	e.counters.pending -= len(spans)
+	e.counters.mu.Unlock()
+
+	if err := e.SpanExporter.ExportSpans(ctx, spans); err != nil {
+		// Spans dropped by an open circuit breaker never reached the exporter.
+		if errors.Is(err, exporter.ErrCircuitOpen) {
+			e.counters.dropped.Add(ctx, int64(len(spans)))
+		} else {
+			e.counters.failures.Add(ctx, 1)
+		}
+
+		return err
+	}
+
+	e.counters.exported.Add(ctx, int64(len(spans)))
+
+	return nil
+}
+
+// countingProcessor bounds the spans handed to the batch processor and counts the drops.
+type countingProcessor struct {
+	// next is the batch processor
+	next sdktrace.SpanProcessor
+
+	// maxQueueSize is the maximum number of spans handed to the batch processor and not yet
+	// exported, the queue size of the batch processor
+	maxQueueSize int
+
+	// counters are the shared counters
+	counters *exportCounters
+}
+
+// NewBatchWithMetrics creates a batch span processor exporting through exp that records, through
+// the given meter provider, the span.exported counter of successfully exported spans, the
+// span.export.failures counter of failed export calls, and the span.dropped counter of spans
+// dropped because the batch queue was full or an export circuit breaker was open.
+//
+// The batch processor drops spans silently when its queue is full, so the processor bounds
+// the spans it hands to the batch processor and not yet handed to the exporter to the queue
+// size itself: a span ending while the queue is full is dropped and counted right away, so
+// the batch processor never drops nor blocks. Spans still queued when the processor shuts
+// down are counted as dropped as well.
+//
+// Example usage:
+//
+//	bsp, err := processor.NewBatchWithMetrics(exporter, otel.GetMeterProvider())
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(bsp))
+//
+// Parameters:
+//   - exp: The exporter receiving the batches
+//   - provider: The meter provider used to create the instruments
+//   - opts: Options of the batch span processor
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The batch processor
+//   - error: Any error encountered while creating the instruments
+func NewBatchWithMetrics(exp sdktrace.SpanExporter, provider metric.MeterProvider, opts ...sdktrace.BatchSpanProcessorOption) (sdktrace.SpanProcessor, error) {
+	meter := provider.Meter(meterName)
+
+	exported, err := meter.Int64Counter("span.exported", metric.WithDescription("Number of spans successfully exported"))
+	if err != nil {
+		return nil, err
+	}
+
+	dropped, err := meter.Int64Counter("span.dropped", metric.WithDescription("Number of spans dropped before export"))
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := meter.Int64Counter("span.export.failures", metric.WithDescription("Number of failed span exports"))
+	if err != nil {
+		return nil, err
+	}
+
+	counters := &exportCounters{exported: exported, dropped: dropped, failures: failures}
+	bsp := sdktrace.NewBatchSpanProcessor(&countingExporter{SpanExporter: exp, counters: counters}, opts...)
+
+	return &countingProcessor{next: bsp, maxQueueSize: maxQueueSize(opts), counters: counters}, nil
+}
+
+// maxQueueSize returns the queue size of a batch processor created with the given options,
+// resolved like the SDK does from the OTEL_BSP_MAX_QUEUE_SIZE environment variable, the
+// default and the options.
+//
+// Parameters:
+//   - opts: Options of the batch span processor
+//
+// Returns:
+//   - int: The queue size
+func maxQueueSize(opts []sdktrace.BatchSpanProcessorOption) int {
+	o := sdktrace.BatchSpanProcessorOptions{MaxQueueSize: sdktrace.DefaultMaxQueueSize}
+
+	if size, err := strconv.Atoi(os.Getenv(maxQueueSizeEnvKey)); err == nil && size > 0 {
+		o.MaxQueueSize = size
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.MaxQueueSize
+}
+
+// OnStart delegates to the batch processor.
+func (p *countingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd hands the span to the batch processor, or drops and counts it when the queue is full.
+func (p *countingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+
+	p.counters.mu.Lock()
+	if p.counters.stopped {
+		p.counters.mu.Unlock()
+		return
+	}
+
+	full := p.counters.pending >= p.maxQueueSize
+	if !full {
+		p.counters.pending++
+	}
+	p.counters.mu.Unlock()
+
+	if full {
+		p.counters.dropped.Add(context.Background(), 1)
+		return
+	}
+
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the batch processor and records the spans it could not export.
+func (p *countingProcessor) Shutdown(ctx context.Context) error {
+	err := p.next.Shutdown(ctx)
+
+	p.counters.mu.Lock()
+	dropped := p.counters.pending
+	p.counters.pending = 0
+	p.counters.stopped = true
+	p.counters.mu.Unlock()
+
+	if dropped > 0 {
+		p.counters.dropped.Add(ctx, int64(dropped))
+	}
+
+	return err
+}
+
+// ForceFlush flushes the batch processor.
+func (p *countingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// gatedExporter signals each export as it starts and holds it until released.
+type gatedExporter struct {
+	*tracetest.InMemoryExporter
+
+	// started receives a value when an export starts
+	started chan struct{}
+
+	// release lets the held exports complete once closed
+	release chan struct{}
+}
+
+// ExportSpans signals the export and waits for the release before exporting the spans.
+func (e *gatedExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.started <- struct{}{}
+	<-e.release
+
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+// failingExporter fails every export.
+type failingExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+// ExportSpans fails.
+func (failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return errors.New("collector unavailable")
+}
+
+func TestBatchWithMetricsCountsDroppedSpansOnQueueOverflow(t *testing.T) {
+	meterProvider, reader := newTestMeterProvider()
+	exp := &gatedExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), started: make(chan struct{}, 2), release: make(chan struct{})}
+
+	p, err := NewBatchWithMetrics(exp, meterProvider,
+		sdktrace.WithMaxQueueSize(1),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBatchTimeout(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewBatchWithMetrics: %v", err)
+	}
+	defer func() { _ = p.Shutdown(context.Background()) }()
+
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "exported.first")
+	span.End()
+	<-exp.started
+
+	// The first span is being exported: the second one fills the queue, the third overflows it.
+	for _, name := range []string{"exported.second", "dropped"} {
+		_, span := tracer.Start(context.Background(), name)
+		span.End()
+	}
+
+	close(exp.release)
+	if err := p.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	if got := len(exp.GetSpans()); got != 2 {
+		t.Errorf("got %d exported spans, want 2", got)
+	}
+
+	metrics := collect(t, reader)
+
+	if got := sum(metrics, "span.exported"); got != 2 {
+		t.Errorf("span.exported = %d, want 2", got)
+	}
+
+	if got := sum(metrics, "span.dropped"); got != 1 {
+		t.Errorf("span.dropped = %d, want 1", got)
+	}
+
+	if got := sum(metrics, "span.export.failures"); got != 0 {
+		t.Errorf("span.export.failures = %d, want 0", got)
+	}
+}
+
+func TestBatchWithMetricsCountsExportFailures(t *testing.T) {
+	meterProvider, reader := newTestMeterProvider()
+
+	p, err := NewBatchWithMetrics(failingExporter{tracetest.NewInMemoryExporter()}, meterProvider)
+	if err != nil {
+		t.Fatalf("NewBatchWithMetrics: %v", err)
+	}
+
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "orders.create")
+	span.End()
+
+	_ = p.ForceFlush(context.Background())
+	_ = p.Shutdown(context.Background())
+
+	metrics := collect(t, reader)
+
+	if got := sum(metrics, "span.export.failures"); got != 1 {
+		t.Errorf("span.export.failures = %d, want 1", got)
+	}
+
+	if got := sum(metrics, "span.exported"); got != 0 {
+		t.Errorf("span.exported = %d, want 0", got)
+	}
+}
+
+func TestMaxQueueSizeFromEnvironment(t *testing.T) {
+	t.Setenv(maxQueueSizeEnvKey, "512")
+
+	if got := maxQueueSize(nil); got != 512 {
+		t.Errorf("maxQueueSize = %d, want the environment value 512", got)
+	}
+
+	if got := maxQueueSize([]sdktrace.BatchSpanProcessorOption{sdktrace.WithMaxQueueSize(64)}); got != 64 {
+		t.Errorf("maxQueueSize = %d, want the option value 64", got)
+	}
+}
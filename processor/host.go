@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// HostAttributeKeys defines the span attribute keys identifying the instance producing the spans.
+type HostAttributeKeys struct {
+	// HostName is the key of the host name attribute, skipped when empty
+	HostName string
+
+	// PodName is the key of the Kubernetes pod name attribute, skipped when empty
+	PodName string
+}
+
+var (
+	// DefaultHostAttributeKeys are the OpenTelemetry semantic convention keys of the host attributes
+	DefaultHostAttributeKeys = HostAttributeKeys{HostName: "host.name", PodName: "k8s.pod.name"}
+)
+
+// NewHost creates a span processor that attaches the host name and, when running in Kubernetes,
+// the pod name to every span when it starts, so queries can tell which instance produced a span.
+// The pod name is read from the POD_NAME environment variable, usually set through the downward
+// API, and falls back to HOSTNAME inside a pod. Both are resolved once, when the processor is
+// created. Unlike resource attributes, these attributes are recorded on the spans themselves.
+//
+// Example usage:
+//
+//	p := processor.NewHost(processor.DefaultHostAttributeKeys)
+//
+// Parameters:
+//   - keys: The attribute keys to use
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The enriching processor
+func NewHost(keys HostAttributeKeys) sdktrace.SpanProcessor {
+	var attrs []attribute.KeyValue
+
+	if hostName, err := os.Hostname(); err == nil && keys.HostName != "" {
+		attrs = append(attrs, attribute.String(keys.HostName, hostName))
+	}
+
+	if podName := podName(); podName != "" && keys.PodName != "" {
+		attrs = append(attrs, attribute.String(keys.PodName, podName))
+	}
+
+	return &envAttributesProcessor{attributes: attrs}
+}
+
+// podName resolves the name of the Kubernetes pod running the process, empty outside Kubernetes.
+func podName() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return os.Getenv("HOSTNAME")
+	}
+
+	return ""
+}
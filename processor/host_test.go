@@ -0,0 +1,67 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// hostAttributes returns the attributes set by the host processor with the keys on a started span.
+func hostAttributes(t *testing.T, keys HostAttributeKeys) map[string]string {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewHost(keys)), sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	attrs := map[string]string{}
+	for key, value := range attributeMap(recorder.Ended()[0]) {
+		attrs[string(key)] = value.AsString()
+	}
+
+	return attrs
+}
+
+func TestHostSetsHostAndPodNames(t *testing.T) {
+	hostName, err := os.Hostname()
+	if err != nil {
+		t.Skipf("no host name: %v", err)
+	}
+
+	t.Setenv("POD_NAME", "orders-7d9f-abcde")
+
+	attrs := hostAttributes(t, DefaultHostAttributeKeys)
+	if attrs["host.name"] != hostName || attrs["k8s.pod.name"] != "orders-7d9f-abcde" {
+		t.Errorf("attributes = %v, want host.name=%s and the pod name", attrs, hostName)
+	}
+}
+
+func TestHostUsesConfiguredKeys(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("HOSTNAME", "orders-7d9f-abcde")
+
+	attrs := hostAttributes(t, HostAttributeKeys{PodName: "pod"})
+	if len(attrs) != 1 || attrs["pod"] != "orders-7d9f-abcde" {
+		t.Errorf("attributes = %v, want the pod name from HOSTNAME under pod only", attrs)
+	}
+}
+
+func TestHostOmitsThePodNameOutsideKubernetes(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	if _, ok := hostAttributes(t, DefaultHostAttributeKeys)["k8s.pod.name"]; ok {
+		t.Error("k8s.pod.name set outside Kubernetes")
+	}
+}
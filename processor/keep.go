@@ -0,0 +1,150 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KeepRule decides whether a recorded span that was not sampled is exported anyway.
+type KeepRule func(s sdktrace.ReadOnlySpan) bool
+
+// keepProcessor forces the export of the recorded but not sampled spans matching a rule,
+// and drops the other ones.
+type keepProcessor struct {
+	// next is the processor receiving the sampled and kept spans
+	next sdktrace.SpanProcessor
+
+	// rules decide which not sampled spans are kept
+	rules []KeepRule
+}
+
+// keptSpan is a not sampled span reported as sampled, so the exporting processor exports it.
+type keptSpan struct {
+	sdktrace.ReadOnlySpan
+
+	// spanContext is the span context with the sampled flag set
+	spanContext trace.SpanContext
+}
+
+// SpanContext returns the span context with the sampled flag set.
+func (s keptSpan) SpanContext() trace.SpanContext {
+	return s.spanContext
+}
+
+// KeepSlow creates a rule keeping the spans lasting at least threshold.
+//
+// Parameters:
+//   - threshold: The duration from which spans are kept
+//
+// Returns:
+//   - KeepRule: The latency rule
+func KeepSlow(threshold time.Duration) KeepRule {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return s.EndTime().Sub(s.StartTime()) >= threshold
+	}
+}
+
+// KeepErrors creates a rule keeping the spans with an error status.
+//
+// Returns:
+//   - KeepRule: The error rule
+func KeepErrors() KeepRule {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return s.Status().Code == codes.Error
+	}
+}
+
+// NewKeep creates a span processor keeping spans even when head sampling dropped them: a
+// recorded span that is not sampled and matches any of the rules is handed to the next
+// processor flagged as sampled, so it is exported, while the other ones are discarded.
+// Sampled spans are always handed over. Spans dropped by the sampler are never recorded, so
+// the sampler must record them with sampler.NewRecordOnly for this processor to see them.
+// Rules must be combined in a single processor, as each processor discards the spans its
+// rules do not keep. This is a local heuristic, not tail sampling: the kept span is exported
+// without the other spans of its trace.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSampler(sampler.NewRecordOnly(sdktrace.TraceIDRatioBased(0.1))),
+//		sdktrace.WithSpanProcessor(processor.NewKeep(bsp, processor.KeepErrors(), processor.KeepSlow(2*time.Second))),
+//	)
+//
+// Parameters:
+//   - next: The processor receiving the spans to export, typically the exporting processor
+//   - rules: The rules deciding which not sampled spans are exported
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The keeping processor
+func NewKeep(next sdktrace.SpanProcessor, rules ...KeepRule) sdktrace.SpanProcessor {
+	return &keepProcessor{next: next, rules: rules}
+}
+
+// NewLatencyKeep creates a span processor exporting the not sampled spans lasting at least
+// threshold, see NewKeep.
+//
+// Parameters:
+//   - next: The processor receiving the spans to export, typically the exporting processor
+//   - threshold: The duration from which not sampled spans are exported
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The latency keeping processor
+func NewLatencyKeep(next sdktrace.SpanProcessor, threshold time.Duration) sdktrace.SpanProcessor {
+	return NewKeep(next, KeepSlow(threshold))
+}
+
+// NewErrorKeep creates a span processor exporting the not sampled spans with an error status,
+// an approximation of error-biased tail sampling without a collector, see NewKeep.
+//
+// Parameters:
+//   - next: The processor receiving the spans to export, typically the exporting processor
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The error keeping processor
+func NewErrorKeep(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+	return NewKeep(next, KeepErrors())
+}
+
+// OnStart delegates to the next processor.
+func (p *keepProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd delegates sampled spans to the next processor, as well as not sampled ones matching
+// a rule, flagged as sampled.
+func (p *keepProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sc := s.SpanContext()
+	if sc.IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+
+	for _, rule := range p.rules {
+		if rule(s) {
+			p.next.OnEnd(keptSpan{
+				ReadOnlySpan: s,
+				spanContext:  sc.WithTraceFlags(sc.TraceFlags().WithSampled(true)),
+			})
+			return
+		}
+	}
+}
+
+// Shutdown shuts down the next processor.
+func (p *keepProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *keepProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
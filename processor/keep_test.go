@@ -0,0 +1,89 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goxkit/tracing/sampler"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newUnsampledTracer returns a tracer recording every span without sampling it, whose spans
+// go through the keep processor with the rules before the returned exporter.
+func newUnsampledTracer(t *testing.T, rules ...KeepRule) (trace.Tracer, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler.NewRecordOnly(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(NewKeep(sdktrace.NewSimpleSpanProcessor(exp), rules...)),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp.Tracer("test"), exp
+}
+
+// endAfter starts a span and ends it after the given duration.
+func endAfter(tracer trace.Tracer, name string, d time.Duration) {
+	start := time.Now()
+
+	_, span := tracer.Start(context.Background(), name, trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(d)))
+}
+
+func TestLatencyKeepExportsSlowUnsampledSpans(t *testing.T) {
+	tracer, exp := newUnsampledTracer(t, KeepSlow(100*time.Millisecond))
+
+	endAfter(tracer, "db.query.slow", 250*time.Millisecond)
+	endAfter(tracer, "db.query.fast", time.Millisecond)
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "db.query.slow" {
+		t.Fatalf("got %d exported spans, want the slow span only", len(spans))
+	}
+
+	if !spans[0].SpanContext.IsSampled() {
+		t.Error("the kept span is not flagged as sampled")
+	}
+}
+
+func TestErrorKeepExportsErroredUnsampledSpans(t *testing.T) {
+	tracer, exp := newUnsampledTracer(t, KeepErrors())
+
+	_, span := tracer.Start(context.Background(), "orders.pay")
+	span.SetStatus(codes.Error, "card declined")
+	span.End()
+
+	_, span = tracer.Start(context.Background(), "orders.list")
+	span.End()
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "orders.pay" {
+		t.Fatalf("got %d exported spans, want the errored span only", len(spans))
+	}
+
+	if !spans[0].SpanContext.IsSampled() {
+		t.Error("the kept span is not flagged as sampled")
+	}
+}
+
+func TestKeepHandsOverSampledSpans(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewErrorKeep(sdktrace.NewSimpleSpanProcessor(exp))))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.list")
+	span.End()
+
+	if len(exp.GetSpans()) != 1 {
+		t.Errorf("got %d exported spans, want the sampled span", len(exp.GetSpans()))
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// LeakedSpanEvent is the event recorded on the spans ended by the leak watchdog
+	LeakedSpanEvent = "span.leaked"
+
+	// minLeakCheckInterval is the shortest interval between two checks of the open spans
+	minLeakCheckInterval = time.Millisecond
+)
+
+// spanKey identifies a span tracked by the leak watchdog.
+type spanKey struct {
+	// traceID is the trace ID of the span
+	traceID trace.TraceID
+
+	// spanID is the span ID of the span
+	spanID trace.SpanID
+}
+
+// leakWatchdogProcessor ends the spans still open after a maximum lifetime.
+type leakWatchdogProcessor struct {
+	// maxLifetime is the lifetime after which open spans are ended
+	maxLifetime time.Duration
+
+	// mu guards open
+	mu sync.Mutex
+
+	// open holds the spans started and not yet ended
+	open map[spanKey]sdktrace.ReadWriteSpan
+
+	// stop stops the watchdog goroutine
+	stop chan struct{}
+
+	// stopOnce guarantees stop is closed a single time
+	stopOnce sync.Once
+}
+
+// NewLeakWatchdog creates a span processor tracking the started spans and ending those still
+// open after maxLifetime, typically spans whose End call is missing, which would otherwise
+// leak memory and never be exported. A span ended this way carries the span.leaked event with
+// its lifetime and an error status, surfacing the instrumentation bug in the backend. Open
+// spans are checked every half maxLifetime, so a leaked span is ended between maxLifetime and
+// 1.5 times maxLifetime after its start, and at least every millisecond for shorter lifetimes.
+// maxLifetime must exceed the duration of the longest legitimate operation. The watchdog stops
+// when the processor is shut down.
+//
+// Example usage:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewLeakWatchdog(5*time.Minute)),
+//		sdktrace.WithBatcher(exporter),
+//	)
+//
+// Parameters:
+//   - maxLifetime: The lifetime after which open spans are ended
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The watchdog processor
+func NewLeakWatchdog(maxLifetime time.Duration) sdktrace.SpanProcessor {
+	p := &leakWatchdogProcessor{
+		maxLifetime: maxLifetime,
+		open:        map[spanKey]sdktrace.ReadWriteSpan{},
+		stop:        make(chan struct{}),
+	}
+
+	go p.watch()
+
+	return p
+}
+
+// OnStart tracks the span.
+func (p *leakWatchdogProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	p.mu.Lock()
+	p.open[keyOf(s)] = s
+	p.mu.Unlock()
+}
+
+// OnEnd stops tracking the span.
+func (p *leakWatchdogProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	delete(p.open, keyOf(s))
+	p.mu.Unlock()
+}
+
+// Shutdown stops the watchdog and forgets the open spans.
+func (p *leakWatchdogProcessor) Shutdown(context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+
+	p.mu.Lock()
+	p.open = map[spanKey]sdktrace.ReadWriteSpan{}
+	p.mu.Unlock()
+
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *leakWatchdogProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// watch periodically ends the leaked spans until the processor is shut down.
+func (p *leakWatchdogProcessor) watch() {
+	ticker := time.NewTicker(max(p.maxLifetime/2, minLeakCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.endLeaked(now)
+		}
+	}
+}
+
+// endLeaked flags and ends the spans open for longer than the maximum lifetime. Spans are
+// ended outside the lock, as ending them calls OnEnd.
+//
+// Parameters:
+//   - now: The time of the check
+func (p *leakWatchdogProcessor) endLeaked(now time.Time) {
+	var leaked []sdktrace.ReadWriteSpan
+
+	p.mu.Lock()
+	for _, s := range p.open {
+		if now.Sub(s.StartTime()) >= p.maxLifetime {
+			leaked = append(leaked, s)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, s := range leaked {
+		lifetime := now.Sub(s.StartTime())
+		s.AddEvent(LeakedSpanEvent, trace.WithAttributes(attribute.String("span.lifetime", lifetime.String())))
+		s.SetStatus(codes.Error, "span not ended after "+p.maxLifetime.String())
+		s.End()
+	}
+}
+
+// keyOf returns the key identifying the span.
+//
+// Parameters:
+//   - s: The span
+//
+// Returns:
+//   - spanKey: The key of the span
+func keyOf(s sdktrace.ReadOnlySpan) spanKey {
+	sc := s.SpanContext()
+	return spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newWatchedTracer returns a provider whose spans are tracked by a leak watchdog with the
+// given maximum lifetime, along with the watchdog and a recorder of the ended spans.
+func newWatchedTracer(t *testing.T, maxLifetime time.Duration) (*sdktrace.TracerProvider, *leakWatchdogProcessor, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	watchdog := NewLeakWatchdog(maxLifetime)
+	recorder := tracetest.NewSpanRecorder()
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(watchdog), sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp, watchdog.(*leakWatchdogProcessor), recorder
+}
+
+func TestLeakWatchdogEndsLeakedSpans(t *testing.T) {
+	tp, _, recorder := newWatchedTracer(t, 20*time.Millisecond)
+
+	_, _ = tp.Tracer("test").Start(context.Background(), "orders.leaked")
+
+	var ended []sdktrace.ReadOnlySpan
+	for deadline := time.Now().Add(time.Second); len(ended) == 0 && time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		ended = recorder.Ended()
+	}
+
+	if len(ended) != 1 {
+		t.Fatal("the leaked span was not ended")
+	}
+
+	if ended[0].Status().Code != codes.Error {
+		t.Errorf("status = %v, want an error", ended[0].Status().Code)
+	}
+
+	if events := ended[0].Events(); len(events) != 1 || events[0].Name != LeakedSpanEvent {
+		t.Errorf("events = %v, want the %s event", events, LeakedSpanEvent)
+	}
+}
+
+func TestLeakWatchdogKeepsRecentAndEndedSpans(t *testing.T) {
+	tp, watchdog, recorder := newWatchedTracer(t, time.Hour)
+
+	_, open := tp.Tracer("test").Start(context.Background(), "orders.open")
+	defer open.End()
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	watchdog.endLeaked(time.Now())
+
+	if ended := recorder.Ended(); len(ended) != 1 || len(ended[0].Events()) != 0 {
+		t.Error("the watchdog ended a span younger than the maximum lifetime")
+	}
+
+	watchdog.endLeaked(time.Now().Add(2 * time.Hour))
+
+	if ended := recorder.Ended(); len(ended) != 2 || ended[1].Name() != "orders.open" {
+		t.Error("the watchdog did not end the span older than the maximum lifetime")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// minDurationProcessor drops the spans shorter than a threshold instead of handing them to the next processor.
+type minDurationProcessor struct {
+	// next is the processor receiving the kept spans
+	next sdktrace.SpanProcessor
+
+	// minDuration is the duration below which spans are dropped
+	minDuration time.Duration
+}
+
+// NewMinDuration creates a span processor that drops ended spans lasting less than minDuration
+// instead of passing them to the next processor, cutting the noise and cost of very short
+// internal spans. Spans with an error status are kept regardless of their duration. Children
+// of a dropped span still reference it as parent, so backends may show them detached.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewMinDuration(bsp, time.Millisecond)),
+//	)
+//
+// Parameters:
+//   - next: The processor receiving the kept spans, typically the exporting processor
+//   - minDuration: The duration below which spans are dropped
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The filtering processor
+func NewMinDuration(next sdktrace.SpanProcessor, minDuration time.Duration) sdktrace.SpanProcessor {
+	return &minDurationProcessor{next: next, minDuration: minDuration}
+}
+
+// OnStart delegates to the next processor.
+func (p *minDurationProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd delegates the span to the next processor unless it is shorter than the threshold
+// and not in error.
+func (p *minDurationProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.EndTime().Sub(s.StartTime()) < p.minDuration && s.Status().Code != codes.Error {
+		return
+	}
+
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the next processor.
+func (p *minDurationProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *minDurationProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
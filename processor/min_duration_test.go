@@ -0,0 +1,43 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMinDurationDropsShortSpans(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewMinDuration(next, time.Millisecond)
+	})
+
+	start := time.Now()
+	end := func(span trace.Span, d time.Duration) { span.End(trace.WithTimestamp(start.Add(d))) }
+
+	_, short := tracer.Start(context.Background(), "cache.get", trace.WithTimestamp(start))
+	end(short, 100*time.Microsecond)
+
+	_, long := tracer.Start(context.Background(), "db.query", trace.WithTimestamp(start))
+	end(long, 10*time.Millisecond)
+
+	_, failed := tracer.Start(context.Background(), "cache.set", trace.WithTimestamp(start))
+	failed.SetStatus(codes.Error, "connection refused")
+	end(failed, 100*time.Microsecond)
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+
+	if len(names) != 2 || names[0] != "db.query" || names[1] != "cache.set" {
+		t.Errorf("kept spans = %v, want [db.query cache.set]", names)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	// uuidPattern matches UUIDs in span names
+	uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+	// hexIDPattern matches long hexadecimal identifiers, such as object IDs or hashes, in span names
+	hexIDPattern = regexp.MustCompile(`(^|[^0-9A-Za-z])[0-9a-fA-F]{16,}`)
+
+	// numericIDPattern matches numbers of three digits or more not glued to a word, such as order IDs
+	numericIDPattern = regexp.MustCompile(`(^|[^A-Za-z0-9])[0-9]{3,}`)
+)
+
+// nameCardinalityProcessor warns about span names likely to embed identifiers.
+type nameCardinalityProcessor struct {
+	// logger writes the warnings
+	logger *zap.Logger
+
+	// reported holds the name patterns already reported
+	reported sync.Map
+}
+
+// NewNameCardinality creates a span processor that detects span names likely to embed
+// identifiers, such as "process-order-12345" or names containing UUIDs, which make the number
+// of distinct span names explode in the backend. The identifiers of an offending name are
+// replaced with placeholders to get its pattern, e.g. "process-order-{id}", and a warning is
+// logged through the given logger the first time each pattern is seen. It is meant to catch
+// instrumentation mistakes during development.
+//
+// Example usage:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewNameCardinality(cfgs.Logger)),
+//	)
+//
+// Parameters:
+//   - logger: The logger writing the warnings
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The name checking processor
+func NewNameCardinality(logger *zap.Logger) sdktrace.SpanProcessor {
+	return &nameCardinalityProcessor{logger: logger}
+}
+
+// OnStart does nothing.
+func (p *nameCardinalityProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd warns about the name of the span, renamed or not, once per offending pattern.
+func (p *nameCardinalityProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	name := s.Name()
+
+	pattern := namePattern(name)
+	if pattern == name {
+		return
+	}
+
+	if _, loaded := p.reported.LoadOrStore(pattern, struct{}{}); loaded {
+		return
+	}
+
+	p.logger.Warn("span name likely contains an identifier, use a low-cardinality name and set the identifier as an attribute",
+		zap.String("span_name", name),
+		zap.String("span_name_pattern", pattern),
+	)
+}
+
+// Shutdown does nothing.
+func (p *nameCardinalityProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *nameCardinalityProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// namePattern replaces the identifiers found in a span name with the {id} placeholder.
+//
+// Parameters:
+//   - name: The span name
+//
+// Returns:
+//   - string: The name pattern, equal to the name when it holds no identifier
+func namePattern(name string) string {
+	pattern := uuidPattern.ReplaceAllString(name, "{id}")
+	pattern = hexIDPattern.ReplaceAllString(pattern, "${1}{id}")
+
+	return numericIDPattern.ReplaceAllString(pattern, "${1}{id}")
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNameCardinalityWarnsOncePerPattern(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	tracer, _ := newTestTracer(t, func(sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewNameCardinality(zap.New(core))
+	})
+
+	for _, name := range []string{
+		"orders.get.123e4567-e89b-12d3-a456-426614174000",
+		"orders.get.9f0c1e2d-3b4a-4c5d-8e6f-7a8b9c0d1e2f",
+		"orders.list",
+	} {
+		_, span := tracer.Start(context.Background(), name)
+		span.End()
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d warnings, want 1", len(entries))
+	}
+
+	if got := entries[0].ContextMap()["span_name_pattern"]; got != "orders.get.{id}" {
+		t.Errorf("span_name_pattern = %v, want orders.get.{id}", got)
+	}
+}
+
+func TestNamePattern(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "process-order-12345", want: "process-order-{id}"},
+		{name: "GET /users/42", want: "GET /users/42"},
+		{name: "GET /users/4242", want: "GET /users/{id}"},
+		{name: "cache.get 507f1f77bcf86cd799439011", want: "cache.get {id}"},
+		{name: "http2.request", want: "http2.request"},
+		{name: "orders.create", want: "orders.create"},
+	}
+
+	for _, tt := range tests {
+		if got := namePattern(tt.name); got != tt.want {
+			t.Errorf("namePattern(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
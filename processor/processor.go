@@ -0,0 +1,32 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package processor provides OpenTelemetry span processors that transform spans
+// before they are exported. Processors that rewrite span data decorate the next
+// processor in the pipeline (typically the batch processor that feeds the exporter),
+// since ended spans are read-only once they reach a processor.
+package processor
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// attributesSpan is a read-only span view exposing a rewritten set of attributes.
+type attributesSpan struct {
+	sdktrace.ReadOnlySpan
+
+	// attributes replaces the attributes of the wrapped span
+	attributes []attribute.KeyValue
+}
+
+// Attributes returns the rewritten attributes.
+func (s *attributesSpan) Attributes() []attribute.KeyValue {
+	return s.attributes
+}
+
+// withAttributes returns a read-only view of the span exposing the given attributes.
+func withAttributes(span sdktrace.ReadOnlySpan, attrs []attribute.KeyValue) sdktrace.ReadOnlySpan {
+	return &attributesSpan{ReadOnlySpan: span, attributes: attrs}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestTracer returns a tracer whose spans go through the processor built by wrap around a
+// recorder, which receives the spans the processor hands over.
+func newTestTracer(t *testing.T, wrap func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor) (trace.Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(wrap(recorder)))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp.Tracer("test"), recorder
+}
+
+// attributeMap indexes the attributes of a span by key.
+func attributeMap(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes()))
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+// newTestMeterProvider returns a meter provider whose metrics are read on demand by collect.
+func newTestMeterProvider() (*sdkmetric.MeterProvider, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+
+	return sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), reader
+}
+
+// collect reads the metrics of the reader, indexed by name.
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	return metrics
+}
+
+// sum returns the total of the data points of an int64 counter, zero when it was not recorded.
+func sum(metrics map[string]metricdata.Aggregation, name string) int64 {
+	data, _ := metrics[name].(metricdata.Sum[int64])
+
+	var total int64
+	for _, point := range data.DataPoints {
+		total += point.Value
+	}
+
+	return total
+}
+
+func TestWithAttributesKeepsTheSpanData(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor { return next })
+
+	_, span := tracer.Start(context.Background(), "orders.create", trace.WithAttributes(attribute.String("a", "1")))
+	span.End()
+
+	original := recorder.Ended()[0]
+	view := withAttributes(original, []attribute.KeyValue{attribute.String("b", "2")})
+
+	if got := attributeMap(view); len(got) != 1 || got["b"].AsString() != "2" {
+		t.Errorf("attributes = %v, want b=2 only", view.Attributes())
+	}
+
+	if view.Name() != original.Name() || !view.SpanContext().Equal(original.SpanContext()) {
+		t.Error("the view does not expose the data of the span")
+	}
+}
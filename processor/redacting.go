@@ -0,0 +1,90 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// RedactedValue replaces the value of redacted attributes
+	RedactedValue = "[REDACTED]"
+)
+
+// redactingProcessor masks configured attributes before handing spans to the next processor.
+type redactingProcessor struct {
+	// next is the processor receiving the redacted spans
+	next sdktrace.SpanProcessor
+
+	// keys is the set of attribute keys to redact
+	keys map[attribute.Key]struct{}
+}
+
+// NewRedacting creates a span processor that replaces the value of the configured
+// attribute keys with RedactedValue before passing ended spans to the next processor.
+// It prevents sensitive data (emails, tokens, etc.) from leaving the service.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewRedacting(bsp, "user.email", "auth.token")),
+//	)
+//
+// Parameters:
+//   - next: The processor receiving the redacted spans, typically the exporting processor
+//   - keys: The attribute keys to redact
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The redacting processor
+func NewRedacting(next sdktrace.SpanProcessor, keys ...string) sdktrace.SpanProcessor {
+	set := make(map[attribute.Key]struct{}, len(keys))
+
+	for _, key := range keys {
+		set[attribute.Key(key)] = struct{}{}
+	}
+
+	return &redactingProcessor{next: next, keys: set}
+}
+
+// OnStart delegates to the next processor.
+func (p *redactingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd redacts the configured attributes and delegates to the next processor.
+func (p *redactingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := s.Attributes()
+	redacted := make([]attribute.KeyValue, len(attrs))
+	changed := false
+
+	for i, kv := range attrs {
+		if _, ok := p.keys[kv.Key]; ok {
+			kv = kv.Key.String(RedactedValue)
+			changed = true
+		}
+
+		redacted[i] = kv
+	}
+
+	if changed {
+		s = withAttributes(s, redacted)
+	}
+
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the next processor.
+func (p *redactingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *redactingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRedactingMasksConfiguredAttributes(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewRedacting(next, "user.email", "auth.token")
+	})
+
+	_, span := tracer.Start(context.Background(), "users.login", trace.WithAttributes(
+		attribute.String("user.email", "jane@example.com"),
+		attribute.Int("auth.token", 1234),
+		attribute.String("user.id", "u-1"),
+	))
+	span.End()
+
+	attrs := attributeMap(recorder.Ended()[0])
+	for _, key := range []attribute.Key{"user.email", "auth.token"} {
+		if got := attrs[key].Emit(); got != RedactedValue {
+			t.Errorf("%s = %q, want %s", key, got, RedactedValue)
+		}
+	}
+
+	if got := attrs["user.id"].AsString(); got != "u-1" {
+		t.Errorf("user.id = %q, want it untouched", got)
+	}
+}
+
+func TestRedactingPassesSpansWithoutSensitiveAttributes(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewRedacting(next, "user.email")
+	})
+
+	_, span := tracer.Start(context.Background(), "orders.list", trace.WithAttributes(attribute.Int("orders.count", 3)))
+	span.End()
+
+	if _, rewritten := recorder.Ended()[0].(*attributesSpan); rewritten {
+		t.Error("a span without sensitive attributes was rewritten")
+	}
+}
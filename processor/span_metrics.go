@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// meterName is the instrumentation scope name used for the meters of this package
+	meterName = "github.com/goxkit/tracing/processor"
+)
+
+// spanMetricsProcessor records rate, error and duration (RED) metrics from ended spans.
+type spanMetricsProcessor struct {
+	// calls counts the ended spans
+	calls metric.Int64Counter
+
+	// errors counts the ended spans with an error status
+	errors metric.Int64Counter
+
+	// duration records the duration of the ended spans
+	duration metric.Float64Histogram
+}
+
+// NewSpanMetrics creates a span processor that derives RED metrics from ended spans,
+// so request rate, errors and duration are available without instrumenting twice.
+// Every metric is keyed by the span.name and status.code attributes:
+//   - span.calls: counter of ended spans
+//   - span.errors: counter of ended spans with an error status
+//   - span.duration: histogram of span durations in seconds
+//
+// Parameters:
+//   - provider: The meter provider used to create the instruments
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The metrics processor
+//   - error: Any error encountered while creating the instruments
+func NewSpanMetrics(provider metric.MeterProvider) (sdktrace.SpanProcessor, error) {
+	meter := provider.Meter(meterName)
+
+	calls, err := meter.Int64Counter("span.calls", metric.WithDescription("Number of ended spans"))
+	if err != nil {
+		return nil, err
+	}
+
+	errorCount, err := meter.Int64Counter("span.errors", metric.WithDescription("Number of ended spans with an error status"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		"span.duration",
+		metric.WithDescription("Duration of ended spans"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spanMetricsProcessor{calls: calls, errors: errorCount, duration: duration}, nil
+}
+
+// OnStart does nothing.
+func (p *spanMetricsProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd records the metrics of the ended span.
+func (p *spanMetricsProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	ctx := context.Background()
+	attrs := metric.WithAttributes(
+		attribute.String("span.name", s.Name()),
+		attribute.String("status.code", s.Status().Code.String()),
+	)
+
+	p.calls.Add(ctx, 1, attrs)
+	p.duration.Record(ctx, s.EndTime().Sub(s.StartTime()).Seconds(), attrs)
+
+	if s.Status().Code == codes.Error {
+		p.errors.Add(ctx, 1, attrs)
+	}
+}
+
+// Shutdown does nothing.
+func (p *spanMetricsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *spanMetricsProcessor) ForceFlush(context.Context) error {
+	return nil
+}
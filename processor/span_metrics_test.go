@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSpanMetricsRecordsRED(t *testing.T) {
+	meterProvider, reader := newTestMeterProvider()
+
+	p, err := NewSpanMetrics(meterProvider)
+	if err != nil {
+		t.Fatalf("NewSpanMetrics: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	tracer := tp.Tracer("test")
+
+	for i := 0; i < 3; i++ {
+		_, span := tracer.Start(context.Background(), "orders.create")
+		if i == 0 {
+			span.SetStatus(codes.Error, "failed")
+		}
+		span.End()
+	}
+
+	metrics := collect(t, reader)
+
+	if got := sum(metrics, "span.calls"); got != 3 {
+		t.Errorf("span.calls = %d, want 3", got)
+	}
+
+	if got := sum(metrics, "span.errors"); got != 1 {
+		t.Errorf("span.errors = %d, want 1", got)
+	}
+
+	histogram, ok := metrics["span.duration"].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("span.duration = %T, want a float64 histogram", metrics["span.duration"])
+	}
+
+	var count uint64
+	for _, point := range histogram.DataPoints {
+		count += point.Count
+
+		if name, _ := point.Attributes.Value("span.name"); name != attribute.StringValue("orders.create") {
+			t.Errorf("span.name = %s, want orders.create", name.Emit())
+		}
+	}
+
+	if count != 3 {
+		t.Errorf("span.duration count = %d, want 3", count)
+	}
+
+	calls := metrics["span.calls"].(metricdata.Sum[int64])
+	if len(calls.DataPoints) != 2 {
+		t.Errorf("got %d span.calls series, want one per status code", len(calls.DataPoints))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// TenantIDKey is the span attribute key holding the tenant resolved from the context
+	TenantIDKey = attribute.Key("tenant.id")
+)
+
+// tenantAttributeProcessor sets the tenant found in the parent context on every started span.
+type tenantAttributeProcessor struct {
+	// contextKey is the key of the context value holding the tenant
+	contextKey any
+}
+
+// NewTenantAttribute creates a span processor that, when a span starts, reads the tenant from
+// the parent context value stored under the given key, typically by an authentication
+// middleware, and sets it as the tenant.id attribute, so multi-tenant services get every span
+// tagged without doing it in each handler. The value may be a string or a fmt.Stringer; spans
+// whose context holds no such value, or an empty one, are left untouched.
+//
+// Example usage:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewTenantAttribute(auth.TenantKey{})),
+//	)
+//
+// Parameters:
+//   - contextKey: The key of the context value holding the tenant
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The enriching processor
+func NewTenantAttribute(contextKey any) sdktrace.SpanProcessor {
+	return &tenantAttributeProcessor{contextKey: contextKey}
+}
+
+// OnStart sets the tenant of the parent context as the tenant.id span attribute.
+func (p *tenantAttributeProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	var tenant string
+
+	switch v := parent.Value(p.contextKey).(type) {
+	case string:
+		tenant = v
+	case fmt.Stringer:
+		tenant = v.String()
+	}
+
+	if tenant != "" {
+		s.SetAttributes(TenantIDKey.String(tenant))
+	}
+}
+
+// OnEnd does nothing.
+func (p *tenantAttributeProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *tenantAttributeProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *tenantAttributeProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// tenantKey is the context key of the tenant in the tests.
+type tenantKey struct{}
+
+// tenant is a tenant implementing fmt.Stringer.
+type tenant struct {
+	// id is the tenant ID
+	id string
+}
+
+// String returns the tenant ID.
+func (t tenant) String() string {
+	return t.id
+}
+
+func TestTenantAttributeTagsSpansWithTheContextTenant(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewTenantAttribute(tenantKey{})),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	contexts := []context.Context{
+		context.WithValue(context.Background(), tenantKey{}, "acme"),
+		context.WithValue(context.Background(), tenantKey{}, tenant{id: "globex"}),
+		context.WithValue(context.Background(), tenantKey{}, ""),
+		context.WithValue(context.Background(), tenantKey{}, 42),
+		context.Background(),
+	}
+	want := []string{"acme", "globex", "", "", ""}
+
+	for _, ctx := range contexts {
+		_, span := tp.Tracer("test").Start(ctx, "orders.create")
+		span.End()
+	}
+
+	for i, span := range recorder.Ended() {
+		got, ok := attributeMap(span)[TenantIDKey]
+		if want[i] == "" && ok {
+			t.Errorf("span %d: %s = %s, want none", i, TenantIDKey, got.AsString())
+		}
+
+		if want[i] != "" && got.AsString() != want[i] {
+			t.Errorf("span %d: %s = %s, want %s", i, TenantIDKey, got.AsString(), want[i])
+		}
+	}
+}
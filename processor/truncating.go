@@ -0,0 +1,104 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// TruncatedAttributeKey flags the spans having at least one truncated attribute value
+	TruncatedAttributeKey = "truncated"
+
+	// truncationEllipsis is appended to truncated attribute values
+	truncationEllipsis = "..."
+)
+
+// truncatingProcessor shortens oversized string attribute values before handing spans to the next processor.
+type truncatingProcessor struct {
+	// next is the processor receiving the truncated spans
+	next sdktrace.SpanProcessor
+
+	// maxLength is the maximum length in bytes of string attribute values
+	maxLength int
+}
+
+// NewTruncating creates a span processor that shortens string attribute values longer than
+// maxLength bytes before passing ended spans to the next processor. Truncated values end with
+// an ellipsis and keep within maxLength, and the span gets the truncated=true attribute, so
+// large SQL statements or JSON bodies do not make the collector reject the export payload.
+//
+// Example usage:
+//
+//	bsp := sdktrace.NewBatchSpanProcessor(exporter)
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(processor.NewTruncating(bsp, 4096)),
+//	)
+//
+// Parameters:
+//   - next: The processor receiving the truncated spans, typically the exporting processor
+//   - maxLength: The maximum length in bytes of string attribute values
+//
+// Returns:
+//   - sdktrace.SpanProcessor: The truncating processor
+func NewTruncating(next sdktrace.SpanProcessor, maxLength int) sdktrace.SpanProcessor {
+	return &truncatingProcessor{next: next, maxLength: maxLength}
+}
+
+// OnStart delegates to the next processor.
+func (p *truncatingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd truncates the oversized attribute values and delegates to the next processor.
+func (p *truncatingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := s.Attributes()
+	truncated := make([]attribute.KeyValue, len(attrs), len(attrs)+1)
+	changed := false
+
+	for i, kv := range attrs {
+		if kv.Value.Type() == attribute.STRING && len(kv.Value.AsString()) > p.maxLength {
+			kv = kv.Key.String(p.truncate(kv.Value.AsString()))
+			changed = true
+		}
+
+		truncated[i] = kv
+	}
+
+	if changed {
+		s = withAttributes(s, append(truncated, attribute.Bool(TruncatedAttributeKey, true)))
+	}
+
+	p.next.OnEnd(s)
+}
+
+// truncate shortens the value to maxLength bytes including the ellipsis, without splitting
+// a multi-byte character.
+func (p *truncatingProcessor) truncate(value string) string {
+	cut := p.maxLength - len(truncationEllipsis)
+	if cut <= 0 {
+		return value[:max(p.maxLength, 0)]
+	}
+
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+
+	return value[:cut] + truncationEllipsis
+}
+
+// Shutdown shuts down the next processor.
+func (p *truncatingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *truncatingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
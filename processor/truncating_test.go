@@ -0,0 +1,73 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTruncatingShortensLongStrings(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewTruncating(next, 10)
+	})
+
+	_, span := tracer.Start(context.Background(), "db.query", trace.WithAttributes(
+		attribute.String("db.statement", "SELECT * FROM orders WHERE id = 1"),
+		attribute.String("db.system", "postgresql"),
+		attribute.Int("db.rows", 123456789012),
+	))
+	span.End()
+
+	attrs := attributeMap(recorder.Ended()[0])
+	if got := attrs["db.statement"].AsString(); got != "SELECT ..." {
+		t.Errorf("db.statement = %q, want it truncated to 10 bytes with an ellipsis", got)
+	}
+
+	if got := attrs["db.system"].AsString(); got != "postgresql" {
+		t.Errorf("db.system = %q, want a value of the maximum length untouched", got)
+	}
+
+	if got := attrs["db.rows"].AsInt64(); got != 123456789012 {
+		t.Errorf("db.rows = %d, want non-string values untouched", got)
+	}
+
+	if !attrs[TruncatedAttributeKey].AsBool() {
+		t.Errorf("%s=true missing", TruncatedAttributeKey)
+	}
+}
+
+func TestTruncatingKeepsRunesWhole(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewTruncating(next, 8)
+	})
+
+	_, span := tracer.Start(context.Background(), "orders.create", trace.WithAttributes(
+		attribute.String("order.note", "ééééééé"),
+	))
+	span.End()
+
+	got := attributeMap(recorder.Ended()[0])["order.note"].AsString()
+	if got != "éé..." || len(got) > 8 {
+		t.Errorf("order.note = %q, want whole runes within 8 bytes", got)
+	}
+}
+
+func TestTruncatingPassesShortSpans(t *testing.T) {
+	tracer, recorder := newTestTracer(t, func(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+		return NewTruncating(next, 10)
+	})
+
+	_, span := tracer.Start(context.Background(), "orders.list", trace.WithAttributes(attribute.String("order.id", "o-1")))
+	span.End()
+
+	if _, flagged := attributeMap(recorder.Ended()[0])[TruncatedAttributeKey]; flagged {
+		t.Error("a span without long values was flagged as truncated")
+	}
+}
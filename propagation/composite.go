@@ -0,0 +1,132 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package propagation
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// PropagatorsEnvKey is the standard environment variable listing the propagators to use
+	PropagatorsEnvKey = "OTEL_PROPAGATORS"
+
+	// DefaultPropagators is the list of propagators used when OTEL_PROPAGATORS is unset
+	DefaultPropagators = "tracecontext,baggage"
+
+	// DefaultExtractOnlyPropagators is the list of propagators also accepted on extraction
+	// when OTEL_PROPAGATORS is unset, without injecting their headers
+	DefaultExtractOnlyPropagators = "b3,jaeger"
+)
+
+// extractOnly is a propagator injecting with one propagator and extracting with another,
+// so additional inbound formats are accepted without being sent.
+type extractOnly struct {
+	// inject injects the trace context into outbound carriers
+	inject propagation.TextMapPropagator
+
+	// extract extracts the trace context from inbound carriers
+	extract propagation.TextMapPropagator
+}
+
+// Inject injects the trace context with the inject propagator.
+func (p extractOnly) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.inject.Inject(ctx, carrier)
+}
+
+// Extract extracts the trace context with the extract propagator.
+func (p extractOnly) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.extract.Extract(ctx, carrier)
+}
+
+// Fields returns the fields injected by the inject propagator.
+func (p extractOnly) Fields() []string {
+	return p.inject.Fields()
+}
+
+// WithExtractOnly returns a propagator injecting with the named propagators only, and
+// extracting with the extract-only propagators as well, so requests and messages from
+// services still sending another format (e.g. B3 or Jaeger) continue their traces while
+// outbound ones only carry the named formats. The named propagators extract last, so their
+// trace context wins when senders set several formats at once.
+//
+// Example usage:
+//
+//	p := propagation.WithExtractOnly([]string{"tracecontext", "baggage"}, "b3", "jaeger")
+//
+// Parameters:
+//   - names: The propagator names used to inject and extract
+//   - extractOnlyNames: The propagator names only used to extract
+//
+// Returns:
+//   - propagation.TextMapPropagator: The propagator
+func WithExtractOnly(names []string, extractOnlyNames ...string) propagation.TextMapPropagator {
+	return extractOnly{
+		inject:  New(names...),
+		extract: New(append(append([]string{}, extractOnlyNames...), names...)...),
+	}
+}
+
+// New returns a composite propagator made of the named propagators, so inbound requests and
+// messages are extracted whichever of the formats the sender used, and outbound ones carry
+// every format. Supported names are tracecontext, baggage, b3 (single header), b3multi (multiple
+// headers), jaeger and none; unknown names are ignored. B3 extraction accepts both the single
+// and multiple header encodings.
+//
+// On extract, every propagator runs in order and the last one finding a trace context wins,
+// so list the preferred format last when senders may set several formats at once.
+//
+// Parameters:
+//   - names: The propagator names
+//
+// Returns:
+//   - propagation.TextMapPropagator: The composite propagator
+func New(names ...string) propagation.TextMapPropagator {
+	propagators := make([]propagation.TextMapPropagator, 0, len(names))
+
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "b3":
+			propagators = append(propagators, b3.New())
+		case "b3multi":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case "jaeger":
+			propagators = append(propagators, jaeger.Jaeger{})
+		case "none":
+			return propagation.NewCompositeTextMapPropagator()
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
+
+// FromEnv returns the composite propagator listed by the OTEL_PROPAGATORS environment variable
+// (e.g. "tracecontext,baggage,b3"). When it is unset, only the W3C DefaultPropagators are
+// injected, while the DefaultExtractOnlyPropagators (B3 and Jaeger) are also accepted on
+// extraction.
+//
+// Example usage:
+//
+//	otel.SetTextMapPropagator(propagation.FromEnv())
+//
+// Returns:
+//   - propagation.TextMapPropagator: The composite propagator
+func FromEnv() propagation.TextMapPropagator {
+	value := os.Getenv(PropagatorsEnvKey)
+	if strings.TrimSpace(value) == "" {
+		return WithExtractOnly(strings.Split(DefaultPropagators, ","), strings.Split(DefaultExtractOnlyPropagators, ",")...)
+	}
+
+	return New(strings.Split(value, ",")...)
+}
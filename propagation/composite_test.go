@@ -0,0 +1,86 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package propagation
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// upstreamTraceID is the trace ID sent by the upstream services of the tests.
+const upstreamTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+// extractTraceID extracts the trace ID carried by the headers with the propagator.
+func extractTraceID(p propagation.TextMapPropagator, headers map[string]string) string {
+	sc := trace.SpanContextFromContext(p.Extract(context.Background(), propagation.MapCarrier(headers)))
+	if !sc.IsValid() {
+		return ""
+	}
+
+	return sc.TraceID().String()
+}
+
+func TestFromEnvExtractsEveryFormatByDefault(t *testing.T) {
+	t.Setenv(PropagatorsEnvKey, "")
+	p := FromEnv()
+
+	for format, headers := range map[string]map[string]string{
+		"tracecontext": {"traceparent": "00-" + upstreamTraceID + "-00f067aa0ba902b7-01"},
+		"b3 single":    {"b3": upstreamTraceID + "-00f067aa0ba902b7-1"},
+		"b3 multi": {
+			"x-b3-traceid": upstreamTraceID,
+			"x-b3-spanid":  "00f067aa0ba902b7",
+			"x-b3-sampled": "1",
+		},
+		"jaeger": {"uber-trace-id": upstreamTraceID + ":00f067aa0ba902b7:0:1"},
+	} {
+		if got := extractTraceID(p, headers); got != upstreamTraceID {
+			t.Errorf("%s: extracted trace %q, want %s", format, got, upstreamTraceID)
+		}
+	}
+}
+
+func TestFromEnvInjectsW3CByDefault(t *testing.T) {
+	t.Setenv(PropagatorsEnvKey, "")
+
+	fields := FromEnv().Fields()
+	sort.Strings(fields)
+
+	if got := strings.Join(fields, ","); got != "baggage,traceparent,tracestate" {
+		t.Errorf("fields = %s, want the W3C trace context and baggage only", got)
+	}
+}
+
+func TestFromEnvHonorsPropagatorsVariable(t *testing.T) {
+	t.Setenv(PropagatorsEnvKey, "b3multi")
+	p := FromEnv()
+
+	carrier := propagation.MapCarrier{}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	p.Inject(ctx, carrier)
+
+	if carrier.Get("x-b3-traceid") != upstreamTraceID || carrier.Get("traceparent") != "" {
+		t.Errorf("injected headers = %v, want B3 multi headers only", carrier)
+	}
+
+	if got := extractTraceID(p, map[string]string{"traceparent": "00-" + upstreamTraceID + "-00f067aa0ba902b7-01"}); got != "" {
+		t.Errorf("extracted trace %q from traceparent, want the listed formats only", got)
+	}
+}
+
+func TestNewWithNoneDisablesPropagation(t *testing.T) {
+	if fields := New("tracecontext", "none").Fields(); len(fields) != 0 {
+		t.Errorf("fields = %v, want none", fields)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package propagation provides OpenTelemetry TextMapPropagator implementations that extend
+// the standard W3C propagators. They can be registered as the global propagator for HTTP
+// or assigned to the messaging propagators (e.g. amqp.AMQPPropagator) so the same behavior
+// applies to every outbound protocol.
+package propagation
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceBaggagePropagator decorates a TextMapPropagator, adding selected resource
+// attributes as baggage members to the context before injecting it.
+type resourceBaggagePropagator struct {
+	// next is the propagator that performs the actual injection and extraction
+	next propagation.TextMapPropagator
+
+	// members are the baggage members built from the resource attributes
+	members []baggage.Member
+}
+
+// NewResourceBaggagePropagator returns a propagator that, on Inject, adds the configured
+// resource attributes (e.g. cloud.region) as baggage members so downstream services can
+// record them as well. Members already present in the context baggage are left untouched.
+// Extraction is delegated unchanged to the wrapped propagator.
+//
+// The wrapped propagator must include propagation.Baggage for the members to reach the wire.
+// Wrapping a propagator returned by this function replaces its members instead of nesting
+// the decorators, so the propagator can be decorated again on each installation. The
+// installers wire it with options.WithResourceBaggage.
+//
+// Example usage:
+//
+//	res, _ := resource.New(ctx, resource.WithFromEnv())
+//	p := propagation.NewResourceBaggagePropagator(propagation.FromEnv(), res, "cloud.region")
+//	amqp.SetPropagator(p)
+//	otel.SetTextMapPropagator(p)
+//
+// Parameters:
+//   - next: The propagator used to inject and extract the context
+//   - res: The resource holding the attributes to propagate
+//   - keys: The resource attribute keys to propagate as baggage
+//
+// Returns:
+//   - propagation.TextMapPropagator: The decorated propagator
+func NewResourceBaggagePropagator(next propagation.TextMapPropagator, res *resource.Resource, keys ...string) propagation.TextMapPropagator {
+	if decorated, ok := next.(*resourceBaggagePropagator); ok {
+		next = decorated.next
+	}
+
+	members := make([]baggage.Member, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok := res.Set().Value(attribute.Key(key))
+		if !ok {
+			continue
+		}
+
+		member, err := baggage.NewMemberRaw(key, value.Emit())
+		if err != nil {
+			continue
+		}
+
+		members = append(members, member)
+	}
+
+	return &resourceBaggagePropagator{next: next, members: members}
+}
+
+// Inject adds the resource baggage members to the context and delegates to the wrapped propagator.
+func (p *resourceBaggagePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	bag := baggage.FromContext(ctx)
+
+	for _, member := range p.members {
+		if bag.Member(member.Key()).Key() != "" {
+			continue
+		}
+
+		if b, err := bag.SetMember(member); err == nil {
+			bag = b
+		}
+	}
+
+	p.next.Inject(baggage.ContextWithBaggage(ctx, bag), carrier)
+}
+
+// Extract delegates to the wrapped propagator.
+func (p *resourceBaggagePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.next.Extract(ctx, carrier)
+}
+
+// Fields returns the fields used by the wrapped propagator.
+func (p *resourceBaggagePropagator) Fields() []string {
+	return p.next.Fields()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// testResource is the resource whose attributes the tests propagate.
+var testResource = resource.NewSchemaless(
+	attribute.String("cloud.region", "eu-west-1"),
+	attribute.String("service.name", "orders"),
+)
+
+func TestResourceBaggagePropagatorInjectsResourceAttributes(t *testing.T) {
+	p := NewResourceBaggagePropagator(propagation.Baggage{}, testResource, "cloud.region", "missing")
+
+	carrier := propagation.MapCarrier{}
+	p.Inject(context.Background(), carrier)
+
+	bag, err := baggage.Parse(carrier.Get("baggage"))
+	if err != nil {
+		t.Fatalf("parsing the injected baggage: %v", err)
+	}
+
+	if got := bag.Member("cloud.region").Value(); got != "eu-west-1" {
+		t.Errorf("cloud.region = %q, want eu-west-1", got)
+	}
+
+	if bag.Len() != 1 {
+		t.Errorf("got %d baggage members, want 1: %s", bag.Len(), bag)
+	}
+}
+
+func TestResourceBaggagePropagatorKeepsExistingMembers(t *testing.T) {
+	p := NewResourceBaggagePropagator(propagation.Baggage{}, testResource, "cloud.region")
+
+	member, _ := baggage.NewMember("cloud.region", "us-east-1")
+	bag, _ := baggage.New(member)
+
+	carrier := propagation.MapCarrier{}
+	p.Inject(baggage.ContextWithBaggage(context.Background(), bag), carrier)
+
+	injected, _ := baggage.Parse(carrier.Get("baggage"))
+	if got := injected.Member("cloud.region").Value(); got != "us-east-1" {
+		t.Errorf("cloud.region = %q, want the context value us-east-1", got)
+	}
+}
+
+func TestResourceBaggagePropagatorReplacesDecoration(t *testing.T) {
+	first := NewResourceBaggagePropagator(propagation.Baggage{}, testResource, "cloud.region")
+	second := NewResourceBaggagePropagator(first, testResource, "service.name")
+
+	if _, nested := second.(*resourceBaggagePropagator).next.(*resourceBaggagePropagator); nested {
+		t.Fatal("decorating a decorated propagator nested the decorators")
+	}
+
+	carrier := propagation.MapCarrier{}
+	second.Inject(context.Background(), carrier)
+
+	bag, _ := baggage.Parse(carrier.Get("baggage"))
+	if bag.Member("cloud.region").Key() != "" {
+		t.Error("the members of the replaced decoration were injected")
+	}
+
+	if got := bag.Member("service.name").Value(); got != "orders" {
+		t.Errorf("service.name = %q, want orders", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recoverConfig holds the settings of RecoverHandler.
+type recoverConfig struct {
+	// repanic re-raises the panic once it is recorded
+	repanic bool
+}
+
+// RecoverOption configures RecoverHandler.
+type RecoverOption func(*recoverConfig)
+
+// WithRepanic makes RecoverHandler panic again with the recovered value once the panic is
+// recorded and the 500 response written, leaving the final handling to outer middleware or
+// to the HTTP server.
+//
+// Returns:
+//   - RecoverOption: The re-panic option
+func WithRepanic() RecoverOption {
+	return func(c *recoverConfig) {
+		c.repanic = true
+	}
+}
+
+// RecoverHandler returns a middleware recovering the panics of the next handler. The panic is
+// recorded on the span found in the request context as an exception event with its stack trace,
+// the span status is set to error, and a 500 Internal Server Error response is written, so
+// crashes are linked to their trace. It should be placed inside the tracing middleware (e.g.
+// otelhttp.NewHandler) that starts the server span. Panics with http.ErrAbortHandler, used to
+// abort a response on purpose, are propagated untouched.
+//
+// Example usage:
+//
+//	handler := otelhttp.NewHandler(tracing.RecoverHandler(mux), "http-server")
+//
+// Parameters:
+//   - next: The handler to protect
+//   - opts: Options such as WithRepanic
+//
+// Returns:
+//   - http.Handler: The recovering handler
+func RecoverHandler(next http.Handler, opts ...RecoverOption) http.Handler {
+	cfg := &recoverConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			err := fmt.Errorf("panic: %v", rec)
+			span := trace.SpanFromContext(r.Context())
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			if cfg.repanic {
+				panic(rec)
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
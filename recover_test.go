@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// servePanicking serves a request through RecoverHandler within a span, with a handler
+// panicking with the value, and returns the response and the recovered value, if re-panicked.
+func servePanicking(t *testing.T, value any, opts ...RecoverOption) (*httptest.ResponseRecorder, any) {
+	t.Helper()
+
+	handler := RecoverHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(value)
+	}), opts...)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	ctx, span := Tracer("").Start(req.Context(), "GET /orders")
+	defer span.End()
+
+	rec := httptest.NewRecorder()
+
+	var repanicked any
+	func() {
+		defer func() { repanicked = recover() }()
+		handler.ServeHTTP(rec, req.WithContext(ctx))
+	}()
+
+	return rec, repanicked
+}
+
+func TestRecoverHandlerRecordsPanicsWithStack(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	rec, repanicked := servePanicking(t, "nil map")
+
+	if repanicked != nil || rec.Code != http.StatusInternalServerError {
+		t.Fatalf("response = %d with %v re-panicked, want a 500 response", rec.Code, repanicked)
+	}
+
+	span := recorder.Ended()[0]
+	if status := span.Status(); status.Code != codes.Error || status.Description != "panic: nil map" {
+		t.Errorf("status = %v, want the recovered panic", status)
+	}
+
+	events := span.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want the recorded panic", len(events))
+	}
+
+	var stack string
+	for _, kv := range events[0].Attributes {
+		if kv.Key == semconv.ExceptionStacktraceKey {
+			stack = kv.Value.AsString()
+		}
+	}
+
+	if !strings.Contains(stack, "servePanicking") {
+		t.Errorf("exception.stacktrace = %q, want the stack of the panic", stack)
+	}
+}
+
+func TestRecoverHandlerRepanics(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	rec, repanicked := servePanicking(t, "nil map", WithRepanic())
+
+	if repanicked != "nil map" || rec.Code != http.StatusInternalServerError {
+		t.Errorf("response = %d with %v re-panicked, want a 500 response and the panic value", rec.Code, repanicked)
+	}
+
+	if code := recorder.Ended()[0].Status().Code; code != codes.Error {
+		t.Errorf("status = %s, want Error", code)
+	}
+}
+
+func TestRecoverHandlerRepanicsAbortedHandlers(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	_, repanicked := servePanicking(t, http.ErrAbortHandler)
+
+	if repanicked != http.ErrAbortHandler {
+		t.Errorf("re-panicked %v, want http.ErrAbortHandler", repanicked)
+	}
+
+	if code := recorder.Ended()[0].Status().Code; code != codes.Unset {
+		t.Errorf("status = %s, want the aborted request not recorded as an error", code)
+	}
+}
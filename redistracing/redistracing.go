@@ -0,0 +1,103 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package redistracing provides Redis integration for distributed tracing.
+// It implements a go-redis hook that records each command and pipeline as a
+// child span of the caller's context, using the tracer provider installed by
+// the tracing package, so Redis latency shows up in end-to-end traces.
+package redistracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goxkit/tracing"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used for the tracer
+	instrumentationName = "github.com/goxkit/tracing/redistracing"
+)
+
+// Hook implements redis.Hook, starting a span for every command and pipeline.
+type Hook struct{}
+
+// NewHook creates a hook that traces Redis commands with the tracer provider installed by
+// the tracing package, resolved when each span starts.
+//
+// Example usage:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	rdb.AddHook(redistracing.NewHook())
+//
+// Returns:
+//   - *Hook: The tracing hook to register on a go-redis client
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// DialHook passes dialing through untouched; connections are not traced.
+func (h *Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook starts a span named after the command (e.g. GET) around its execution.
+func (h *Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		operation := strings.ToUpper(cmd.Name())
+
+		ctx, span := tracing.Tracer(instrumentationName).Start(
+			ctx,
+			operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", operation),
+			),
+		)
+		defer span.End()
+
+		err := next(ctx, cmd)
+		recordError(span, err)
+
+		return err
+	}
+}
+
+// ProcessPipelineHook starts a single span around the execution of a pipeline.
+func (h *Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.Tracer(instrumentationName).Start(
+			ctx,
+			"PIPELINE",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", "pipeline"),
+				attribute.Int("db.redis.num_cmd", len(cmds)),
+			),
+		)
+		defer span.End()
+
+		err := next(ctx, cmds)
+		recordError(span, err)
+
+		return err
+	}
+}
+
+// recordError marks the span as failed, ignoring redis.Nil which only signals a missing key.
+func recordError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
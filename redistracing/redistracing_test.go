@@ -0,0 +1,140 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package redistracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTracedClient starts a miniredis server and returns a traced client connected to it,
+// with a recorder of the spans ended afterwards installed as the global tracer provider.
+func newTracedClient(t *testing.T) (*redis.Client, *miniredis.Miniredis, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), PoolSize: 1, DisableIdentity: true})
+	client.AddHook(NewHook())
+	t.Cleanup(func() { _ = client.Close() })
+
+	// The connection is opened under an unsampled parent, so the handshake commands are
+	// not recorded.
+	unsampled := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	}))
+	if err := client.Ping(unsampled).Err(); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+
+	return client, server, recorder
+}
+
+// attributeValue returns the string value of the attribute of the span with the given key.
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+func TestGetCreatesChildSpan(t *testing.T) {
+	client, server, recorder := newTracedClient(t)
+	server.Set("order", "42")
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	if got, err := client.Get(ctx, "order").Result(); err != nil || got != "42" {
+		t.Fatalf("GET = %q, %v, want 42", got, err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	get := spans[0]
+	if get.Name() != "GET" || get.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span = %s (%s), want GET (client)", get.Name(), get.SpanKind())
+	}
+
+	if get.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("GET span is not a child of the caller span")
+	}
+
+	if got := attributeValue(get, "db.system"); got != "redis" {
+		t.Errorf("db.system = %q, want redis", got)
+	}
+
+	if got := attributeValue(get, "db.operation"); got != "GET" {
+		t.Errorf("db.operation = %q, want GET", got)
+	}
+}
+
+func TestMissingKeyIsNotAnError(t *testing.T) {
+	client, _, recorder := newTracedClient(t)
+
+	if err := client.Get(context.Background(), "missing").Err(); err != redis.Nil {
+		t.Fatalf("GET error = %v, want redis.Nil", err)
+	}
+
+	if code := recorder.Ended()[0].Status().Code; code == codes.Error {
+		t.Error("a missing key marked the span as failed")
+	}
+}
+
+func TestFailedCommandRecordsError(t *testing.T) {
+	client, server, recorder := newTracedClient(t)
+	server.Set("order", "42")
+
+	if err := client.LPush(context.Background(), "order", "1").Err(); err == nil {
+		t.Fatal("LPUSH on a string succeeded")
+	}
+
+	span := recorder.Ended()[0]
+	if span.Status().Code != codes.Error || len(span.Events()) == 0 {
+		t.Errorf("span status = %s with %d events, want Error with the recorded error", span.Status().Code, len(span.Events()))
+	}
+}
+
+func TestPipelineCreatesSingleSpan(t *testing.T) {
+	client, _, recorder := newTracedClient(t)
+
+	_, err := client.Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.Set(context.Background(), "a", "1", 0)
+		pipe.Incr(context.Background(), "b")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("pipeline: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "PIPELINE" {
+		t.Fatalf("got %d spans, want a single PIPELINE span", len(spans))
+	}
+
+	if got := attributeValue(spans[0], "db.redis.num_cmd"); got != "2" {
+		t.Errorf("db.redis.num_cmd = %q, want 2", got)
+	}
+}
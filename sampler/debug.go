@@ -0,0 +1,97 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// forcedSamplingKey is the context key marking contexts whose spans are always sampled.
+type forcedSamplingKey struct{}
+
+// debugSampler samples the spans of forced contexts, and follows the base sampler otherwise.
+type debugSampler struct {
+	// base takes the decision for the spans of contexts that are not forced
+	base sdktrace.Sampler
+
+	// baggageKey is the baggage member forcing sampling when true, empty to ignore baggage
+	baggageKey string
+}
+
+// ForceSampling returns a copy of the context whose spans are always sampled by the sampler
+// created with NewDebug, e.g. for a request asking to be traced with a debug header. The trace
+// flags then propagate the decision to downstream services.
+//
+// Parameters:
+//   - ctx: The parent context
+//
+// Returns:
+//   - context.Context: The context forcing sampling
+func ForceSampling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcedSamplingKey{}, true)
+}
+
+// NewDebug creates a sampler forcing the sampling of spans started from a context marked with
+// ForceSampling, or whose baggage holds the member baggageKey with a true value, regardless of
+// the base sampler and of the parent span; other spans follow the base sampler. It lets clients
+// have a request traced on demand to reproduce an issue, see the http package DebugTrace middleware.
+//
+// Example usage:
+//
+//	s := sampler.NewDebug(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.01)), "debug.trace")
+//
+// Parameters:
+//   - base: The sampler deciding for spans that are not forced
+//   - baggageKey: The baggage member forcing sampling when true, empty to ignore baggage
+//
+// Returns:
+//   - sdktrace.Sampler: The debug sampler
+func NewDebug(base sdktrace.Sampler, baggageKey string) sdktrace.Sampler {
+	return &debugSampler{base: base, baggageKey: baggageKey}
+}
+
+// ShouldSample samples forced spans and delegates the decision for the other ones.
+func (s *debugSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if !s.forced(p.ParentContext) {
+		return s.base.ShouldSample(p)
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// Description returns the description of the sampler.
+func (s *debugSampler) Description() string {
+	return fmt.Sprintf("Debug{base:%s,baggage:%s}", s.base.Description(), s.baggageKey)
+}
+
+// forced reports whether the spans of the context must be sampled.
+//
+// Parameters:
+//   - ctx: The parent context of the span
+//
+// Returns:
+//   - bool: Whether sampling is forced
+func (s *debugSampler) forced(ctx context.Context) bool {
+	if forced, _ := ctx.Value(forcedSamplingKey{}).(bool); forced {
+		return true
+	}
+
+	if s.baggageKey == "" {
+		return false
+	}
+
+	forced, _ := strconv.ParseBool(baggage.FromContext(ctx).Member(s.baggageKey).Value())
+
+	return forced
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// decideIn returns the decision of the sampler for a span started from ctx.
+func decideIn(ctx context.Context, s sdktrace.Sampler) sdktrace.SamplingDecision {
+	return s.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: ctx,
+		TraceID:       traceID,
+		Name:          "GET /orders",
+	}).Decision
+}
+
+func TestDebugSamplesForcedContexts(t *testing.T) {
+	s := NewDebug(sdktrace.NeverSample(), "debug.trace")
+
+	if got := decideIn(ForceSampling(context.Background()), s); got != sdktrace.RecordAndSample {
+		t.Errorf("forced context decision = %v, want RecordAndSample", got)
+	}
+
+	if got := decideIn(context.Background(), s); got != sdktrace.Drop {
+		t.Errorf("decision = %v, want the Drop decision of the base sampler", got)
+	}
+}
+
+func TestDebugSamplesContextsWithTheBaggageMember(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		want  sdktrace.SamplingDecision
+	}{
+		{key: "debug.trace", value: "true", want: sdktrace.RecordAndSample},
+		{key: "debug.trace", value: "1", want: sdktrace.RecordAndSample},
+		{key: "debug.trace", value: "false", want: sdktrace.Drop},
+		{key: "debug.trace", value: "yes", want: sdktrace.Drop},
+		{key: "tenant.id", value: "true", want: sdktrace.Drop},
+	}
+
+	s := NewDebug(sdktrace.NeverSample(), "debug.trace")
+
+	for _, tt := range tests {
+		member, _ := baggage.NewMember(tt.key, tt.value)
+		bag, _ := baggage.New(member)
+
+		if got := decideIn(baggage.ContextWithBaggage(context.Background(), bag), s); got != tt.want {
+			t.Errorf("baggage %s=%s decision = %v, want %v", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDebugIgnoresBaggageWithoutKey(t *testing.T) {
+	member, _ := baggage.NewMember("debug.trace", "true")
+	bag, _ := baggage.New(member)
+
+	if got := decideIn(baggage.ContextWithBaggage(context.Background(), bag), NewDebug(sdktrace.NeverSample(), "")); got != sdktrace.Drop {
+		t.Errorf("decision = %v, want Drop", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var (
+	// defaultDynamic is the package-wide dynamic sampler adjusted with SetSamplingRatio
+	defaultDynamic = NewDynamicRatio(1)
+)
+
+// DynamicRatio is a sampler sampling a fraction of the traces, based on the trace ID like
+// sdktrace.TraceIDRatioBased, whose ratio can be changed at runtime, e.g. to sample every
+// trace during an incident without redeploying.
+type DynamicRatio struct {
+	// ratio holds the current ratio
+	ratio atomic.Pointer[float64]
+
+	// sampler holds the sampler of the current ratio
+	sampler atomic.Pointer[sdktrace.Sampler]
+}
+
+// NewDynamicRatio creates a sampler sampling the given fraction of the traces until the
+// ratio is changed with SetRatio.
+//
+// Parameters:
+//   - ratio: The initial fraction of traces to sample, between 0 and 1
+//
+// Returns:
+//   - *DynamicRatio: The dynamic sampler
+func NewDynamicRatio(ratio float64) *DynamicRatio {
+	s := &DynamicRatio{}
+	s.SetRatio(ratio)
+	return s
+}
+
+// SetRatio changes the fraction of traces sampled by the following sampling decisions.
+// Ratios are clamped between 0 and 1.
+//
+// Parameters:
+//   - ratio: The fraction of traces to sample
+func (s *DynamicRatio) SetRatio(ratio float64) {
+	ratio = min(max(ratio, 0), 1)
+	sampler := sdktrace.TraceIDRatioBased(ratio)
+
+	s.ratio.Store(&ratio)
+	s.sampler.Store(&sampler)
+}
+
+// Ratio returns the fraction of traces currently sampled.
+//
+// Returns:
+//   - float64: The current ratio
+func (s *DynamicRatio) Ratio() float64 {
+	return *s.ratio.Load()
+}
+
+// ShouldSample delegates the decision to the sampler of the current ratio.
+func (s *DynamicRatio) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return (*s.sampler.Load()).ShouldSample(p)
+}
+
+// Description returns the description of the sampler.
+func (s *DynamicRatio) Description() string {
+	return fmt.Sprintf("DynamicRatio{%g}", s.Ratio())
+}
+
+// Dynamic returns the package-wide dynamic sampler, adjusted with SetSamplingRatio. It samples
+// every trace until the ratio is changed.
+//
+// Example usage:
+//
+//	tracing.Install(cfgs, options.WithSampler(sdktrace.ParentBased(sampler.Dynamic())))
+//
+// Returns:
+//   - *DynamicRatio: The package-wide dynamic sampler
+func Dynamic() *DynamicRatio {
+	return defaultDynamic
+}
+
+// SetSamplingRatio changes the fraction of traces sampled by the package-wide dynamic sampler.
+//
+// Parameters:
+//   - ratio: The fraction of traces to sample, between 0 and 1
+func SetSamplingRatio(ratio float64) {
+	defaultDynamic.SetRatio(ratio)
+}
+
+// RatioHandler returns an HTTP handler exposing the ratio of a dynamic sampler, to be mounted
+// on an admin endpoint. GET requests return the current ratio; POST and PUT requests set the
+// ratio given by the ratio query or form parameter and return the new ratio.
+//
+// Example usage:
+//
+//	adminMux.Handle("/admin/sampling", sampler.RatioHandler(sampler.Dynamic()))
+//	// curl -X PUT 'http://localhost:8081/admin/sampling?ratio=1'
+//
+// Parameters:
+//   - s: The dynamic sampler to expose
+//
+// Returns:
+//   - http.Handler: The admin handler
+func RatioHandler(s *DynamicRatio) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut:
+			ratio, err := strconv.ParseFloat(r.FormValue("ratio"), 64)
+			if err != nil || ratio < 0 || ratio > 1 {
+				http.Error(w, "ratio must be a number between 0 and 1", http.StatusBadRequest)
+				return
+			}
+			s.SetRatio(ratio)
+		default:
+			w.Header().Set("Allow", "GET, POST, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprintf(w, "%g\n", s.Ratio())
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestDynamicRatioAppliesUpdatedRatios(t *testing.T) {
+	s := NewDynamicRatio(0)
+
+	if got := decide(s, "orders.create"); got != sdktrace.Drop {
+		t.Errorf("decision at ratio 0 = %v, want Drop", got)
+	}
+
+	s.SetRatio(1)
+
+	if got := decide(s, "orders.create"); got != sdktrace.RecordAndSample {
+		t.Errorf("decision at ratio 1 = %v, want RecordAndSample", got)
+	}
+
+	s.SetRatio(3)
+
+	if got := s.Ratio(); got != 1 {
+		t.Errorf("Ratio() = %g, want ratios clamped to 1", got)
+	}
+
+	if got := s.Description(); got != "DynamicRatio{1}" {
+		t.Errorf("Description() = %q, want DynamicRatio{1}", got)
+	}
+}
+
+func TestSetSamplingRatioUpdatesTheDefaultSampler(t *testing.T) {
+	previous := Dynamic().Ratio()
+	t.Cleanup(func() { SetSamplingRatio(previous) })
+
+	SetSamplingRatio(0)
+
+	if got := decide(Dynamic(), "orders.create"); got != sdktrace.Drop {
+		t.Errorf("decision = %v, want Drop after setting the ratio to 0", got)
+	}
+}
+
+func TestRatioHandler(t *testing.T) {
+	s := NewDynamicRatio(1)
+	handler := RatioHandler(s)
+
+	serve := func(method, ratio string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/sampling", strings.NewReader(url.Values{"ratio": {ratio}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if rec := serve(http.MethodPost, "0.25"); rec.Code != http.StatusOK || rec.Body.String() != "0.25\n" || s.Ratio() != 0.25 {
+		t.Errorf("POST 0.25 = %d %q with ratio %g, want the ratio updated", rec.Code, rec.Body.String(), s.Ratio())
+	}
+
+	if rec := serve(http.MethodGet, ""); rec.Body.String() != "0.25\n" {
+		t.Errorf("GET = %q, want the current ratio", rec.Body.String())
+	}
+
+	for _, ratio := range []string{"", "high", "1.5"} {
+		if rec := serve(http.MethodPut, ratio); rec.Code != http.StatusBadRequest || s.Ratio() != 0.25 {
+			t.Errorf("PUT %q = %d with ratio %g, want a rejected update", ratio, rec.Code, s.Ratio())
+		}
+	}
+
+	if rec := serve(http.MethodDelete, ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d, want 405", rec.Code)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// DefaultJaegerRefreshInterval is the default interval between two fetches of the sampling strategy
+	DefaultJaegerRefreshInterval = time.Minute
+)
+
+// probabilisticStrategy is the probabilistic sampling strategy of the Jaeger sampling API.
+type probabilisticStrategy struct {
+	// SamplingRate is the fraction of traces to sample
+	SamplingRate float64 `json:"samplingRate"`
+}
+
+// operationStrategy is the sampling strategy of a single operation.
+type operationStrategy struct {
+	// Operation is the span name the strategy applies to
+	Operation string `json:"operation"`
+
+	// ProbabilisticSampling is the strategy of the operation
+	ProbabilisticSampling probabilisticStrategy `json:"probabilisticSampling"`
+}
+
+// samplingStrategyResponse is the response of the Jaeger sampling strategies endpoint.
+type samplingStrategyResponse struct {
+	// ProbabilisticSampling is the service-wide probabilistic strategy, if any
+	ProbabilisticSampling *probabilisticStrategy `json:"probabilisticSampling"`
+
+	// OperationSampling holds the per-operation strategies, if any
+	OperationSampling *struct {
+		// DefaultSamplingProbability applies to operations without their own strategy
+		DefaultSamplingProbability float64 `json:"defaultSamplingProbability"`
+
+		// PerOperationStrategies are the strategies of specific operations
+		PerOperationStrategies []operationStrategy `json:"perOperationStrategies"`
+	} `json:"operationSampling"`
+}
+
+// remoteStrategy is the sampling strategy resolved from the last successful fetch.
+type remoteStrategy struct {
+	// defaultSampler applies to operations without their own sampler
+	defaultSampler sdktrace.Sampler
+
+	// operations holds the samplers of specific operations, indexed by span name
+	operations map[string]sdktrace.Sampler
+}
+
+// JaegerRemote is a sampler applying the sampling strategy served by a Jaeger sampling
+// server (the /sampling endpoint of the Jaeger agent or collector), so sampling rates
+// can be changed centrally without redeploying. The strategy is fetched periodically in
+// the background; until a strategy has been fetched, and whenever the server returns a
+// strategy that is not probabilistic (e.g. rate limiting), the fallback sampler decides.
+// When the server becomes unreachable, the last fetched strategy remains in use.
+type JaegerRemote struct {
+	// endpoint is the URL of the strategy of the service
+	endpoint string
+
+	// endpointErr is the error encountered while building the endpoint, returned by Refresh
+	endpointErr error
+
+	// fallback decides while no probabilistic strategy is available
+	fallback sdktrace.Sampler
+
+	// client fetches the strategies
+	client *http.Client
+
+	// strategy holds the last fetched strategy
+	strategy atomic.Pointer[remoteStrategy]
+
+	// stop stops the background polling
+	stop context.CancelFunc
+
+	// stopped is closed once the background polling has returned
+	stopped chan struct{}
+
+	// closeOnce guards Close
+	closeOnce sync.Once
+}
+
+// NewJaegerRemote creates a sampler applying the sampling strategy that a Jaeger sampling
+// server serves for the service, and starts fetching it in the background.
+//
+// Example usage:
+//
+//	s := sampler.NewJaegerRemote("http://jaeger-agent:5778/sampling", "orders",
+//		sdktrace.TraceIDRatioBased(0.1), sampler.DefaultJaegerRefreshInterval)
+//	defer s.Close()
+//
+// Parameters:
+//   - serverURL: The URL of the sampling strategies endpoint
+//   - serviceName: The service whose strategy is fetched
+//   - fallback: The sampler deciding while no probabilistic strategy is available
+//   - refreshInterval: The interval between two fetches of the strategy
+//
+// Returns:
+//   - *JaegerRemote: The remote sampler
+func NewJaegerRemote(serverURL, serviceName string, fallback sdktrace.Sampler, refreshInterval time.Duration) *JaegerRemote {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJaegerRefreshInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	endpoint, err := strategyEndpoint(serverURL, serviceName)
+
+	s := &JaegerRemote{
+		endpoint:    endpoint,
+		endpointErr: err,
+		fallback:    fallback,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		stop:        cancel,
+		stopped:     make(chan struct{}),
+	}
+
+	go s.poll(ctx, refreshInterval)
+
+	return s
+}
+
+// strategyEndpoint returns the URL of the strategy of a service, setting the service query
+// parameter on the server URL while keeping its other parameters.
+//
+// Parameters:
+//   - serverURL: The URL of the sampling strategies endpoint
+//   - serviceName: The service whose strategy is fetched
+//
+// Returns:
+//   - string: The URL of the strategy of the service
+//   - error: Any error encountered while parsing the server URL
+func strategyEndpoint(serverURL, serviceName string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing sampling server URL: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("service", serviceName)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// poll fetches the strategy immediately, then at every refresh interval until ctx is done.
+func (s *JaegerRemote) poll(ctx context.Context, refreshInterval time.Duration) {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		// A failed fetch keeps the previous strategy; it is retried at the next tick.
+		_ = s.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the sampling strategy from the server and applies it.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//
+// Returns:
+//   - error: Any error encountered while fetching or decoding the strategy
+func (s *JaegerRemote) Refresh(ctx context.Context) error {
+	if s.endpointErr != nil {
+		return s.endpointErr
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching sampling strategy: unexpected status %d", res.StatusCode)
+	}
+
+	var body samplingStrategyResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	s.strategy.Store(newRemoteStrategy(body))
+
+	return nil
+}
+
+// newRemoteStrategy builds the samplers of a strategy response. The probabilistic samplers
+// only decide for root spans, child spans follow the decision of their parent so traces are
+// never broken. Strategies that are not probabilistic produce an empty strategy, so the
+// fallback sampler decides.
+func newRemoteStrategy(body samplingStrategyResponse) *remoteStrategy {
+	strategy := &remoteStrategy{operations: map[string]sdktrace.Sampler{}}
+
+	switch {
+	case body.OperationSampling != nil:
+		strategy.defaultSampler = probabilistic(body.OperationSampling.DefaultSamplingProbability)
+		for _, op := range body.OperationSampling.PerOperationStrategies {
+			strategy.operations[op.Operation] = probabilistic(op.ProbabilisticSampling.SamplingRate)
+		}
+	case body.ProbabilisticSampling != nil:
+		strategy.defaultSampler = probabilistic(body.ProbabilisticSampling.SamplingRate)
+	}
+
+	return strategy
+}
+
+// probabilistic returns a sampler sampling the given fraction of root spans and following
+// the decision of the parent for child spans.
+func probabilistic(rate float64) sdktrace.Sampler {
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(rate))
+}
+
+// ShouldSample applies the remote strategy of the span name, or the fallback sampler.
+func (s *JaegerRemote) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	strategy := s.strategy.Load()
+	if strategy == nil {
+		return s.fallback.ShouldSample(p)
+	}
+
+	if sampler, ok := strategy.operations[p.Name]; ok {
+		return sampler.ShouldSample(p)
+	}
+
+	if strategy.defaultSampler != nil {
+		return strategy.defaultSampler.ShouldSample(p)
+	}
+
+	return s.fallback.ShouldSample(p)
+}
+
+// Description returns the description of the sampler.
+func (s *JaegerRemote) Description() string {
+	return fmt.Sprintf("JaegerRemote{endpoint:%s,fallback:%s}", s.endpoint, s.fallback.Description())
+}
+
+// Close stops fetching the strategy in the background and waits for the polling to return.
+func (s *JaegerRemote) Close() {
+	s.closeOnce.Do(func() {
+		s.stop()
+		<-s.stopped
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newStrategyServer starts a sampling strategies server answering with the given body for
+// the orders service until the test ends.
+func newStrategyServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("service"); got != "orders" {
+			http.Error(w, "unknown service "+got, http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestJaegerRemote creates a remote sampler falling back to sampling every span, refreshed
+// once by the test, and closed when the test ends.
+func newTestJaegerRemote(t *testing.T, serverURL string) (*JaegerRemote, error) {
+	t.Helper()
+
+	s := NewJaegerRemote(serverURL, "orders", sdktrace.AlwaysSample(), time.Hour)
+	t.Cleanup(s.Close)
+
+	return s, s.Refresh(context.Background())
+}
+
+func TestJaegerRemoteAppliesTheProbabilisticStrategy(t *testing.T) {
+	server := newStrategyServer(t, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0}}`)
+
+	s, err := newTestJaegerRemote(t, server.URL+"/sampling")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	if got := decide(s, "orders.create"); got != sdktrace.Drop {
+		t.Errorf("decision = %v, want Drop at the remote rate 0", got)
+	}
+}
+
+func TestJaegerRemoteAppliesPerOperationStrategies(t *testing.T) {
+	server := newStrategyServer(t, `{"operationSampling":{
+		"defaultSamplingProbability":0,
+		"perOperationStrategies":[{"operation":"orders.create","probabilisticSampling":{"samplingRate":1}}]
+	}}`)
+
+	s, err := newTestJaegerRemote(t, server.URL)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	if got := decide(s, "orders.create"); got != sdktrace.RecordAndSample {
+		t.Errorf("orders.create decision = %v, want RecordAndSample at its operation rate 1", got)
+	}
+
+	if got := decide(s, "orders.list"); got != sdktrace.Drop {
+		t.Errorf("orders.list decision = %v, want Drop at the default rate 0", got)
+	}
+}
+
+func TestJaegerRemoteFallsBackWhenTheServerIsUnreachable(t *testing.T) {
+	server := newStrategyServer(t, "")
+	server.Close()
+
+	s, err := newTestJaegerRemote(t, server.URL)
+	if err == nil {
+		t.Fatal("Refresh succeeded without a server")
+	}
+
+	if got := decide(s, "orders.create"); got != sdktrace.RecordAndSample {
+		t.Errorf("decision = %v, want the one of the fallback sampler", got)
+	}
+}
+
+func TestJaegerRemoteKeepsTheFallbackOnErrorResponses(t *testing.T) {
+	server := newStrategyServer(t, `{"probabilisticSampling":{"samplingRate":0}}`)
+
+	s := NewJaegerRemote(server.URL, "payments", sdktrace.AlwaysSample(), time.Hour)
+	t.Cleanup(s.Close)
+
+	if err := s.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh succeeded on a 404 response")
+	}
+
+	if got := decide(s, "payments.authorize"); got != sdktrace.RecordAndSample {
+		t.Errorf("decision = %v, want the one of the fallback sampler", got)
+	}
+}
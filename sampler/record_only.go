@@ -0,0 +1,51 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordOnlySampler records the spans dropped by the base sampler instead of discarding them.
+type recordOnlySampler struct {
+	// base takes the sampling decision
+	base sdktrace.Sampler
+}
+
+// NewRecordOnly creates a sampler keeping the decision of the base sampler, except that spans
+// it drops are still recorded, without being sampled. Span processors then see every ended
+// span, so a processor such as processor.NewKeep can decide to export some of them
+// after the fact, while the trace flags propagated downstream keep the head decision.
+// Recording every span has a cost proportional to the traffic, even for dropped spans.
+//
+// Example usage:
+//
+//	s := sampler.NewRecordOnly(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.1)))
+//
+// Parameters:
+//   - base: The sampler taking the sampling decision
+//
+// Returns:
+//   - sdktrace.Sampler: The record-only sampler
+func NewRecordOnly(base sdktrace.Sampler) sdktrace.Sampler {
+	return &recordOnlySampler{base: base}
+}
+
+// ShouldSample delegates to the base sampler, turning a drop decision into a record-only one.
+func (s *recordOnlySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.base.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+
+	return result
+}
+
+// Description returns the description of the sampler.
+func (s *recordOnlySampler) Description() string {
+	return fmt.Sprintf("RecordOnly{%s}", s.base.Description())
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRecordOnlyRecordsDroppedSpans(t *testing.T) {
+	if got := decide(NewRecordOnly(sdktrace.NeverSample()), "orders.create"); got != sdktrace.RecordOnly {
+		t.Errorf("dropped span decision = %v, want RecordOnly", got)
+	}
+
+	if got := decide(NewRecordOnly(sdktrace.AlwaysSample()), "orders.create"); got != sdktrace.RecordAndSample {
+		t.Errorf("sampled span decision = %v, want RecordAndSample", got)
+	}
+}
+
+func TestRecordOnlyDescription(t *testing.T) {
+	if got := NewRecordOnly(sdktrace.NeverSample()).Description(); got != "RecordOnly{AlwaysOffSampler}" {
+		t.Errorf("Description = %s", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package sampler provides span samplers complementing the ones of the OpenTelemetry SDK.
+// They can be passed to the installers with options.WithSampler or composed with the
+// sampler configured by default.
+package sampler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Rule overrides the sampling decision of the spans whose name matches a pattern.
+type Rule struct {
+	// Pattern is the span name pattern, where * matches any sequence of characters
+	Pattern string
+
+	// Sampler takes the sampling decision of the matching spans
+	Sampler sdktrace.Sampler
+}
+
+// Drop creates a rule dropping every span whose name matches the pattern.
+//
+// Parameters:
+//   - pattern: The span name pattern, where * matches any sequence of characters
+//
+// Returns:
+//   - Rule: The dropping rule
+func Drop(pattern string) Rule {
+	return Rule{Pattern: pattern, Sampler: sdktrace.NeverSample()}
+}
+
+// Always creates a rule sampling every span whose name matches the pattern.
+//
+// Parameters:
+//   - pattern: The span name pattern, where * matches any sequence of characters
+//
+// Returns:
+//   - Rule: The sampling rule
+func Always(pattern string) Rule {
+	return Rule{Pattern: pattern, Sampler: sdktrace.AlwaysSample()}
+}
+
+// Ratio creates a rule sampling the given fraction of the spans whose name matches the pattern.
+//
+// Parameters:
+//   - pattern: The span name pattern, where * matches any sequence of characters
+//   - fraction: The fraction of spans to sample, between 0 and 1
+//
+// Returns:
+//   - Rule: The ratio rule
+func Ratio(pattern string, fraction float64) Rule {
+	return Rule{Pattern: pattern, Sampler: sdktrace.TraceIDRatioBased(fraction)}
+}
+
+// compiledRule is a rule with its pattern compiled.
+type compiledRule struct {
+	// pattern matches the span names of the rule
+	pattern *regexp.Regexp
+
+	// sampler takes the sampling decision of the matching spans
+	sampler sdktrace.Sampler
+}
+
+// ruleBasedSampler applies the first rule matching the span name, or the base sampler.
+type ruleBasedSampler struct {
+	// base takes the decision for spans matching no rule
+	base sdktrace.Sampler
+
+	// rules are evaluated in order
+	rules []compiledRule
+
+	// description describes the sampler
+	description string
+}
+
+// NewRuleBased creates a sampler overriding the decision of the base sampler by span name.
+// Rules are evaluated in order and the first one whose pattern matches the span name takes
+// the decision, regardless of the base sampler and of the parent span; spans matching no
+// rule follow the base sampler. It keeps hot operations such as health checks out of the
+// traces without sampling decisions in the handlers.
+//
+// Example usage:
+//
+//	s := sampler.NewRuleBased(sdktrace.ParentBased(sdktrace.AlwaysSample()),
+//		sampler.Drop("*/healthz"),
+//		sampler.Ratio("GET /search*", 0.1),
+//	)
+//
+// Parameters:
+//   - base: The sampler deciding for spans matching no rule
+//   - rules: The rules, evaluated in order
+//
+// Returns:
+//   - sdktrace.Sampler: The rule-based sampler
+func NewRuleBased(base sdktrace.Sampler, rules ...Rule) sdktrace.Sampler {
+	compiled := make([]compiledRule, 0, len(rules))
+	patterns := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		compiled = append(compiled, compiledRule{pattern: compilePattern(rule.Pattern), sampler: rule.Sampler})
+		patterns = append(patterns, fmt.Sprintf("%s:%s", rule.Pattern, rule.Sampler.Description()))
+	}
+
+	return &ruleBasedSampler{
+		base:        base,
+		rules:       compiled,
+		description: fmt.Sprintf("RuleBased{base:%s,rules:[%s]}", base.Description(), strings.Join(patterns, ",")),
+	}
+}
+
+// compilePattern compiles a span name pattern where * matches any sequence of characters.
+//
+// Parameters:
+//   - pattern: The span name pattern
+//
+// Returns:
+//   - *regexp.Regexp: The anchored regular expression of the pattern
+func compilePattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// ShouldSample delegates the decision to the sampler of the first matching rule, or the base sampler.
+func (s *ruleBasedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, rule := range s.rules {
+		if rule.pattern.MatchString(p.Name) {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+
+	return s.base.ShouldSample(p)
+}
+
+// Description returns the description of the sampler.
+func (s *ruleBasedSampler) Description() string {
+	return s.description
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sampler
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceID is the trace ID of the sampled spans in the tests.
+var traceID = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+
+// decide returns the decision of the sampler for a root span with the given name.
+func decide(s sdktrace.Sampler, name string) sdktrace.SamplingDecision {
+	return s.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       traceID,
+		Name:          name,
+	}).Decision
+}
+
+func TestRuleBasedDropsMatchingSpans(t *testing.T) {
+	s := NewRuleBased(sdktrace.AlwaysSample(), Drop("*/healthz"))
+
+	if got := decide(s, "GET /healthz"); got != sdktrace.Drop {
+		t.Errorf("GET /healthz decision = %v, want Drop", got)
+	}
+
+	if got := decide(s, "GET /orders"); got != sdktrace.RecordAndSample {
+		t.Errorf("GET /orders decision = %v, want the one of the base sampler", got)
+	}
+
+	if got := decide(s, "GET /healthz/live"); got != sdktrace.RecordAndSample {
+		t.Errorf("GET /healthz/live decision = %v, want the pattern anchored at the end", got)
+	}
+}
+
+func TestRuleBasedAppliesTheFirstMatchingRule(t *testing.T) {
+	s := NewRuleBased(sdktrace.NeverSample(),
+		Always("GET /search*"),
+		Drop("GET /search/suggest"),
+		Ratio("POST *", 0),
+	)
+
+	if got := decide(s, "GET /search/suggest"); got != sdktrace.RecordAndSample {
+		t.Errorf("GET /search/suggest decision = %v, want the first rule", got)
+	}
+
+	if got := decide(s, "POST /orders"); got != sdktrace.Drop {
+		t.Errorf("POST /orders decision = %v, want Drop at ratio 0", got)
+	}
+
+	if got := decide(s, "GET /orders"); got != sdktrace.Drop {
+		t.Errorf("GET /orders decision = %v, want the one of the base sampler", got)
+	}
+}
+
+func TestRuleBasedQuotesPatterns(t *testing.T) {
+	s := NewRuleBased(sdktrace.AlwaysSample(), Drop("GET /v1.0/(status)"))
+
+	if got := decide(s, "GET /v1.0/(status)"); got != sdktrace.Drop {
+		t.Errorf("decision = %v, want the literal name to match", got)
+	}
+
+	if got := decide(s, "GET /v1x0/status"); got != sdktrace.RecordAndSample {
+		t.Errorf("decision = %v, want regular expression characters to be literal", got)
+	}
+}
+
+func TestRuleBasedDescription(t *testing.T) {
+	s := NewRuleBased(sdktrace.AlwaysSample(), Drop("/healthz"))
+
+	if got, want := s.Description(), "RuleBased{base:AlwaysOnSampler,rules:[/healthz:AlwaysOffSampler]}"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+}
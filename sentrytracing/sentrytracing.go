@@ -0,0 +1,155 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package sentrytracing provides Sentry integration for distributed tracing.
+// It implements a span processor forwarding the failures recorded on spans to
+// Sentry as error events carrying the trace context, so errors reported in
+// Sentry can be linked to their trace in the tracing backend.
+package sentrytracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// flushTimeout bounds the delivery of the pending events on shutdown and flush when the
+	// context has no deadline
+	flushTimeout = 2 * time.Second
+)
+
+// Processor forwards the spans ending with an error status to Sentry.
+type Processor struct {
+	// hub captures the events
+	hub *sentry.Hub
+}
+
+// NewProcessor creates a span processor that, when a span ends with an error status, sends an
+// error event to Sentry through the given hub. The event exception is taken from the last
+// exception recorded on the span with RecordError, or from the status description when none
+// was recorded. The trace_id, span_id and span_name tags and the trace context of the event
+// carry the IDs of the span, so the Sentry issue can be matched with the trace.
+//
+// Example usage:
+//
+//	_ = sentry.Init(sentry.ClientOptions{Dsn: dsn})
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(sentrytracing.NewProcessor(sentry.CurrentHub())),
+//		sdktrace.WithBatcher(exporter),
+//	)
+//
+// Parameters:
+//   - hub: The Sentry hub capturing the events, sentry.CurrentHub() when nil
+//
+// Returns:
+//   - *Processor: The Sentry forwarding processor
+func NewProcessor(hub *sentry.Hub) *Processor {
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+
+	return &Processor{hub: hub}
+}
+
+// OnStart does nothing.
+func (p *Processor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd sends an error event to Sentry when the span ended with an error status.
+func (p *Processor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Status().Code != codes.Error {
+		return
+	}
+
+	// The scope sets the trace context of the events it captures, so the event is captured
+	// with a scope carrying the span context rather than the random one of the hub scope.
+	hub := p.hub.Clone()
+	if scope := hub.Scope(); scope != nil {
+		scope.SetPropagationContext(propagationContext(s))
+	}
+
+	hub.CaptureEvent(newEvent(s))
+}
+
+// Shutdown delivers the pending events, waiting until the context is done.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	return p.ForceFlush(ctx)
+}
+
+// ForceFlush delivers the pending events, waiting until the context is done.
+func (p *Processor) ForceFlush(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flushTimeout)
+		defer cancel()
+	}
+
+	p.hub.FlushWithContext(ctx)
+
+	return nil
+}
+
+// newEvent builds the Sentry event of a failed span.
+//
+// Parameters:
+//   - s: The span ended with an error status
+//
+// Returns:
+//   - *sentry.Event: The error event
+func newEvent(s sdktrace.ReadOnlySpan) *sentry.Event {
+	sc := s.SpanContext()
+
+	exception := sentry.Exception{Type: s.Name(), Value: s.Status().Description}
+	for _, event := range s.Events() {
+		if event.Name != "exception" {
+			continue
+		}
+
+		for _, kv := range event.Attributes {
+			switch kv.Key {
+			case "exception.type":
+				exception.Type = kv.Value.AsString()
+			case "exception.message":
+				exception.Value = kv.Value.AsString()
+			}
+		}
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelError
+	event.Timestamp = s.EndTime()
+	event.Message = exception.Value
+	event.Exception = []sentry.Exception{exception}
+	event.Tags = map[string]string{
+		"trace_id":  sc.TraceID().String(),
+		"span_id":   sc.SpanID().String(),
+		"span_name": s.Name(),
+	}
+
+	return event
+}
+
+// propagationContext returns the Sentry trace context of a span.
+//
+// Parameters:
+//   - s: The span
+//
+// Returns:
+//   - sentry.PropagationContext: The trace context holding the IDs of the span and its parent
+func propagationContext(s sdktrace.ReadOnlySpan) sentry.PropagationContext {
+	sc := s.SpanContext()
+
+	pc := sentry.PropagationContext{
+		TraceID: sentry.TraceID(sc.TraceID()),
+		SpanID:  sentry.SpanID(sc.SpanID()),
+	}
+	if parent := s.Parent(); parent.IsValid() {
+		pc.ParentSpanID = sentry.SpanID(parent.SpanID())
+	}
+
+	return pc
+}
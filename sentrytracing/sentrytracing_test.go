@@ -0,0 +1,132 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sentrytracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingTransport is a Sentry transport keeping the events it is asked to send.
+type recordingTransport struct {
+	// mu guards events
+	mu sync.Mutex
+
+	// events are the events sent
+	events []*sentry.Event
+}
+
+// Configure does nothing.
+func (t *recordingTransport) Configure(sentry.ClientOptions) {}
+
+// SendEvent keeps the event.
+func (t *recordingTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+// Flush reports that every event was sent.
+func (t *recordingTransport) Flush(time.Duration) bool {
+	return true
+}
+
+// FlushWithContext reports that every event was sent.
+func (t *recordingTransport) FlushWithContext(context.Context) bool {
+	return true
+}
+
+// Close does nothing.
+func (t *recordingTransport) Close() {}
+
+// sent returns the events sent.
+func (t *recordingTransport) sent() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.events
+}
+
+// newTestProvider returns a tracer provider forwarding its failed spans to a hub sending
+// events through the returned transport.
+func newTestProvider(t *testing.T) (*sdktrace.TracerProvider, *recordingTransport) {
+	t.Helper()
+
+	transport := &recordingTransport{}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: transport})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewProcessor(sentry.NewHub(client, sentry.NewScope()))))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp, transport
+}
+
+func TestProcessorSendsErroredSpansWithTheirTraceID(t *testing.T) {
+	tp, transport := newTestProvider(t)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "orders.create")
+	_, span := tp.Tracer("test").Start(ctx, "orders.pay")
+	span.RecordError(errors.New("card declined"))
+	span.SetStatus(codes.Error, "payment failed")
+	span.End()
+	parent.End()
+
+	events := transport.sent()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want one for the errored span", len(events))
+	}
+
+	event, sc := events[0], span.SpanContext()
+	if event.Tags["trace_id"] != sc.TraceID().String() || event.Tags["span_id"] != sc.SpanID().String() || event.Tags["span_name"] != "orders.pay" {
+		t.Errorf("tags = %v, want the IDs and name of the span", event.Tags)
+	}
+
+	trace := event.Contexts["trace"]
+	if fmt.Sprint(trace["trace_id"]) != sc.TraceID().String() || fmt.Sprint(trace["parent_span_id"]) != parent.SpanContext().SpanID().String() {
+		t.Errorf("trace context = %v, want the trace ID and parent span ID", trace)
+	}
+
+	if exceptions := event.Exception; len(exceptions) != 1 || exceptions[0].Type != "*errors.errorString" || exceptions[0].Value != "card declined" {
+		t.Errorf("exceptions = %v, want the recorded error", exceptions)
+	}
+}
+
+func TestProcessorUsesTheStatusWithoutRecordedError(t *testing.T) {
+	tp, transport := newTestProvider(t)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.pay")
+	span.SetStatus(codes.Error, "payment failed")
+	span.End()
+
+	events := transport.sent()
+	if len(events) != 1 || events[0].Exception[0].Type != "orders.pay" || events[0].Exception[0].Value != "payment failed" {
+		t.Errorf("got %d events, want one named after the span with the status description", len(events))
+	}
+}
+
+func TestProcessorIgnoresSuccessfulSpans(t *testing.T) {
+	tp, transport := newTestProvider(t)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.list")
+	span.SetStatus(codes.Ok, "")
+	span.End()
+
+	if len(transport.sent()) != 0 {
+		t.Errorf("got %d events for a successful span, want none", len(transport.sent()))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var (
+	// ShutdownGracePeriod bounds the flush and shutdown of the tracer providers performed by
+	// HandleShutdownSignals once a signal is received
+	ShutdownGracePeriod = 10 * time.Second
+)
+
+// shutdowner is implemented by tracer providers able to flush their pending spans and shut
+// down, such as *sdktrace.TracerProvider.
+type shutdowner interface {
+	flusher
+	Shutdown(ctx context.Context) error
+}
+
+// HandleShutdownSignals drains the span export queues when the process receives SIGTERM or
+// SIGINT, so the spans still in flight when a pod is stopped during a rolling deploy are not
+// lost. On the first signal, the installed tracer provider, or the global one, is flushed
+// and shut down, then the tenant providers, all within ShutdownGracePeriod, and the result
+// is sent on the returned channel, which is then closed. It stops listening, closing the
+// channel without a value, when ctx is done first.
+//
+// The process is not terminated: receiving these signals no longer exits it, so the
+// application exits itself, typically once the returned channel delivers. The default
+// behavior is restored after the first signal, so a second one terminates the process.
+//
+// Example usage:
+//
+//	drained := tracing.HandleShutdownSignals(ctx)
+//
+//	if err := <-drained; err != nil {
+//		logger.Warn("failed to drain spans", zap.Error(err))
+//	}
+//	os.Exit(0)
+//
+// Parameters:
+//   - ctx: Context stopping the signal handling when done
+//
+// Returns:
+//   - <-chan error: Receives the result of the flush and shutdown, nil on success
+func HandleShutdownSignals(ctx context.Context) <-chan error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+
+	return drainOnSignal(ctx, signals, func() { signal.Stop(signals) })
+}
+
+// drainOnSignal shuts down the tracer providers when a signal is received on the channel.
+//
+// Parameters:
+//   - ctx: Context stopping the wait when done
+//   - signals: The channel receiving the signals
+//   - stop: Stops the delivery of the signals to the channel
+//
+// Returns:
+//   - <-chan error: Receives the result of the shutdown
+func drainOnSignal(ctx context.Context, signals <-chan os.Signal, stop func()) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-signals:
+			stop()
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ShutdownGracePeriod)
+		defer cancel()
+
+		done <- shutdownProviders(shutdownCtx)
+	}()
+
+	return done
+}
+
+// shutdownProviders flushes and shuts down the installed tracer provider and the tenant
+// tracer providers.
+//
+// Parameters:
+//   - ctx: Context bounding the shutdown
+//
+// Returns:
+//   - error: The errors returned by the providers, joined
+func shutdownProviders(ctx context.Context) error {
+	var errs []error
+
+	if s, ok := installedProvider().(shutdowner); ok {
+		errs = append(errs, s.ForceFlush(ctx), s.Shutdown(ctx))
+	}
+
+	errs = append(errs, ShutdownTenants(ctx))
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// drainedProvider is a tracer provider recording its flush and shutdown calls.
+type drainedProvider struct {
+	noop.TracerProvider
+
+	// mu guards calls
+	mu sync.Mutex
+
+	// calls are the names of the methods called, in order
+	calls []string
+
+	// err is returned by Shutdown
+	err error
+}
+
+// ForceFlush records the call.
+func (p *drainedProvider) ForceFlush(context.Context) error {
+	p.record("ForceFlush")
+	return nil
+}
+
+// Shutdown records the call and returns the configured error.
+func (p *drainedProvider) Shutdown(context.Context) error {
+	p.record("Shutdown")
+	return p.err
+}
+
+// record appends a method call.
+func (p *drainedProvider) record(call string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, call)
+}
+
+// called returns the methods called, in order.
+func (p *drainedProvider) called() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.calls...)
+}
+
+// installDrainedProvider stores configs holding a provider recording its flush and shutdown
+// calls, as Install does, until the test ends.
+func installDrainedProvider(t *testing.T) *drainedProvider {
+	t.Helper()
+
+	tp := &drainedProvider{}
+
+	previous := installedConfigs.Swap(&configs.Configs{TracerProvider: tp})
+	t.Cleanup(func() { installedConfigs.Store(previous) })
+
+	return tp
+}
+
+func TestDrainOnSignalFlushesAndShutsDownTheProvider(t *testing.T) {
+	tp := installDrainedProvider(t)
+
+	signals := make(chan os.Signal, 1)
+	stopped := make(chan struct{})
+	drained := drainOnSignal(context.Background(), signals, func() { close(stopped) })
+
+	if len(tp.called()) != 0 {
+		t.Fatal("the provider was shut down before any signal")
+	}
+
+	signals <- syscall.SIGTERM
+
+	if err := <-drained; err != nil {
+		t.Fatalf("drain error = %v, want nil", err)
+	}
+
+	if calls := tp.called(); len(calls) != 2 || calls[0] != "ForceFlush" || calls[1] != "Shutdown" {
+		t.Errorf("calls = %v, want ForceFlush then Shutdown", calls)
+	}
+
+	select {
+	case <-stopped:
+	default:
+		t.Error("the signal delivery was not stopped")
+	}
+
+	if _, ok := <-drained; ok {
+		t.Error("the channel was not closed after the result")
+	}
+}
+
+func TestDrainOnSignalReturnsTheShutdownError(t *testing.T) {
+	tp := installDrainedProvider(t)
+	tp.err = errors.New("export failed")
+
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+
+	if err := <-drainOnSignal(context.Background(), signals, func() {}); !errors.Is(err, tp.err) {
+		t.Errorf("drain error = %v, want %v", err, tp.err)
+	}
+}
+
+func TestDrainOnSignalStopsWithTheContext(t *testing.T) {
+	tp := installDrainedProvider(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	drained := drainOnSignal(ctx, make(chan os.Signal), func() {})
+	cancel()
+
+	if _, ok := <-drained; ok {
+		t.Error("a result was sent although no signal was received")
+	}
+
+	if len(tp.called()) != 0 {
+		t.Errorf("calls = %v, want none", tp.called())
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+//go:build unix
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleShutdownSignalsDrainsOnSIGTERM(t *testing.T) {
+	tp := installDrainedProvider(t)
+
+	drained := HandleShutdownSignals(context.Background())
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("sending SIGTERM: %v", err)
+	}
+
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Errorf("drain error = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the provider was not drained after SIGTERM")
+	}
+
+	if calls := tp.called(); len(calls) != 2 {
+		t.Errorf("calls = %v, want ForceFlush then Shutdown", calls)
+	}
+}
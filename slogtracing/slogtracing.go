@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package slogtracing provides integration between distributed tracing and the standard
+// library log/slog package. It adds the trace context (trace IDs and span IDs) of the
+// context passed to the logging calls to every record, mirroring what the zap package
+// does for zap loggers.
+package slogtracing
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler wraps a slog.Handler to add the trace_id and span_id attributes of the span
+// found in the record context to every record.
+type Handler struct {
+	// next is the handler receiving the enriched records
+	next slog.Handler
+}
+
+// NewHandler wraps a slog.Handler so records logged with a context holding a valid span
+// context carry its trace_id and span_id attributes. Records logged without a context, or
+// with a context without span, are passed through unchanged.
+//
+// Example usage:
+//
+//	logger := slog.New(slogtracing.NewHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	logger.InfoContext(ctx, "Processing request", slog.String("user_id", userID))
+//
+// Parameters:
+//   - next: The handler receiving the enriched records
+//
+// Returns:
+//   - *Handler: The tracing handler
+func NewHandler(next slog.Handler) *Handler {
+	return &Handler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the trace context attributes to the record and passes it to the wrapped handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		record = record.Clone()
+		record.AddAttrs(
+			slog.String("trace_id", spanCtx.TraceID().String()),
+			slog.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a tracing handler wrapping the wrapped handler with the given attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup returns a tracing handler wrapping the wrapped handler with the given group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package slogtracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newTestLogger returns a logger writing JSON records through the handler to the returned buffer.
+func newTestLogger() (*slog.Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+
+	return slog.New(NewHandler(slog.NewJSONHandler(&out, nil))), &out
+}
+
+// decodeRecord decodes the single JSON record written to the buffer.
+func decodeRecord(t *testing.T, out *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	record := map[string]any{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("decoding %q: %v", out.String(), err)
+	}
+
+	return record
+}
+
+func TestHandlerAddsTraceAttributes(t *testing.T) {
+	logger, out := newTestLogger()
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "orders.create")
+	defer span.End()
+
+	logger.With("order_id", "o-1").InfoContext(ctx, "order created")
+
+	record := decodeRecord(t, out)
+	if record["trace_id"] != span.SpanContext().TraceID().String() || record["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("record = %v, want the trace and span IDs of the span", record)
+	}
+
+	if record["order_id"] != "o-1" {
+		t.Errorf("order_id = %v, want the attribute of the logger", record["order_id"])
+	}
+}
+
+func TestHandlerLeavesRecordsWithoutSpanUntouched(t *testing.T) {
+	logger, out := newTestLogger()
+
+	logger.InfoContext(context.Background(), "service started")
+
+	record := decodeRecord(t, out)
+	if _, ok := record["trace_id"]; ok {
+		t.Errorf("record = %v, want no trace attributes without a span", record)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used by the helpers of this package
+	// until a default scope is configured with SetInstrumentationScope
+	instrumentationName = "github.com/goxkit/tracing"
+)
+
+// timeboxedSpan wraps a span that is automatically ended when its deadline expires.
+// Whichever comes first, the caller's End or the deadline, ends the span; the other is a no-op.
+type timeboxedSpan struct {
+	trace.Span
+
+	// timer fires when the span deadline expires
+	timer *time.Timer
+
+	// once guarantees the wrapped span is ended a single time
+	once sync.Once
+}
+
+// End stops the deadline timer and ends the span if it was not already ended by the timeout.
+func (s *timeboxedSpan) End(options ...trace.SpanEndOption) {
+	s.timer.Stop()
+	s.once.Do(func() {
+		s.Span.End(options...)
+	})
+}
+
+// expire flags the span as timed out and ends it.
+func (s *timeboxedSpan) expire() {
+	s.once.Do(func() {
+		s.Span.SetAttributes(attribute.Bool("timeout", true))
+		s.Span.SetStatus(codes.Error, "span deadline exceeded")
+		s.Span.End()
+	})
+}
+
+// borrowedSpan wraps a span owned by a caller, whose End is left to that caller.
+type borrowedSpan struct {
+	trace.Span
+}
+
+// End is a no-op: the span is ended by its owner.
+func (s borrowedSpan) End(...trace.SpanEndOption) {}
+
+// EnsureSpan returns the recording span found in the context, or starts a new one when the
+// context holds none, so middlewares and handlers stacked on the same request do not create
+// duplicate spans. Callers always end the returned span: ending the span of the context is a
+// no-op, as it belongs to whoever started it, while other calls such as SetAttributes and
+// RecordError apply to it. A non-recording span in the context, e.g. a remote parent or a
+// sampled-out span, is not reused, and the new span is its child.
+//
+// Example usage:
+//
+//	ctx, span := tracing.EnsureSpan(r.Context(), "orders.create")
+//	defer span.End()
+//
+// Parameters:
+//   - ctx: The context that may hold a span
+//   - name: The name of the span started when the context holds no recording span
+//
+// Returns:
+//   - context.Context: Context containing the span
+//   - trace.Span: The span of the context with a no-op End, or the new span
+func EnsureSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		return ctx, borrowedSpan{Span: span}
+	}
+
+	return Tracer("").Start(ctx, name)
+}
+
+// RecordSpan records an already completed operation as a span with explicit start and end
+// times, such as an operation timed by another system or an event replayed from historical
+// logs, so traces can be backfilled. The span is started at start, receives the attributes,
+// and is ended at end before RecordSpan returns. The returned context holds the span, so
+// sub-operations can be recorded as its children with RecordSpan too.
+//
+// Example usage:
+//
+//	ctx = tracing.RecordSpan(ctx, "import.row", entry.StartedAt, entry.FinishedAt,
+//		attribute.String("import.file", entry.File),
+//	)
+//
+// Parameters:
+//   - ctx: The parent context
+//   - name: The span name
+//   - start: The time the operation started
+//   - end: The time the operation ended
+//   - attrs: The attributes of the span
+//
+// Returns:
+//   - context.Context: Context containing the ended span
+func RecordSpan(ctx context.Context, name string, start, end time.Time, attrs ...attribute.KeyValue) context.Context {
+	ctx, span := Tracer("").Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	span.End(trace.WithTimestamp(end))
+
+	return ctx
+}
+
+// StartWithTimeout starts a span that is automatically ended if it is still open after
+// the given duration. A span ended by the timeout carries the timeout=true attribute and
+// an error status, so operations that hang never leave spans that are not exported.
+// The returned context is cancelled at the same deadline.
+//
+// Example usage:
+//
+//	ctx, span, cancel := tracing.StartWithTimeout(ctx, "payments.authorize", 5*time.Second)
+//	defer cancel()
+//	defer span.End()
+//
+// Parameters:
+//   - ctx: The parent context
+//   - name: The span name
+//   - d: The maximum lifetime of the span
+//
+// Returns:
+//   - context.Context: Context containing the span, cancelled after d
+//   - trace.Span: The time-boxed span
+//   - context.CancelFunc: Releases the resources of the returned context
+func StartWithTimeout(ctx context.Context, name string, d time.Duration) (context.Context, trace.Span, context.CancelFunc) {
+	ctx, span := Tracer("").Start(ctx, name)
+
+	boxed := &timeboxedSpan{Span: span}
+	boxed.timer = time.AfterFunc(d, boxed.expire)
+
+	ctx, cancel := context.WithTimeout(trace.ContextWithSpan(ctx, boxed), d)
+
+	return ctx, boxed, cancel
+}
+
+// WithSpan runs fn inside a new span. The span context is passed to fn, an error returned
+// by fn is recorded on the span with an error status, otherwise the status is set to Ok,
+// and the span is always ended, even when fn panics.
+//
+// Example usage:
+//
+//	err := tracing.WithSpan(ctx, "orders.process_payment", func(ctx context.Context) error {
+//		return payments.Charge(ctx, order)
+//	})
+//
+// Parameters:
+//   - ctx: The parent context
+//   - name: The span name
+//   - fn: The function to run inside the span
+//
+// Returns:
+//   - error: The error returned by fn
+func WithSpan(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := Tracer("").Start(ctx, name)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+// WithSpanTimeout runs fn inside a new span with a context bounded by the given timeout.
+// When fn fails after this timeout expired, whether it returns the context error or another
+// one, a "timeout" event and the timeout=true attribute are recorded on the span with an
+// error status. A deadline or cancellation inherited from the parent context is not reported
+// as a timeout, and neither is a timeout fn recovered from by returning nil. Other errors
+// returned by fn are recorded as in WithSpan. The span is always ended.
+//
+// Example usage:
+//
+//	err := tracing.WithSpanTimeout(ctx, "inventory.reserve", 2*time.Second, func(ctx context.Context) error {
+//		return inventory.Reserve(ctx, order)
+//	})
+//
+// Parameters:
+//   - ctx: The parent context
+//   - name: The span name
+//   - d: The timeout of the operation
+//   - fn: The function to run inside the span
+//
+// Returns:
+//   - error: The error returned by fn
+func WithSpanTimeout(ctx context.Context, name string, d time.Duration, fn func(ctx context.Context) error) error {
+	ctx, span := Tracer("").Start(ctx, name)
+	defer span.End()
+
+	// The cause is unique to this call, so it tells this timeout apart from the
+	// deadlines of the parent context, including those of enclosing calls.
+	timeout := errors.New("span timeout exceeded")
+
+	ctx, cancel := context.WithTimeoutCause(ctx, d, timeout)
+	defer cancel()
+
+	err := fn(ctx)
+
+	if err != nil && context.Cause(ctx) == timeout {
+		span.AddEvent("timeout", trace.WithAttributes(attribute.String("timeout", d.String())))
+		span.SetAttributes(attribute.Bool("timeout", true))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, context.DeadlineExceeded.Error())
+
+		return err
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+// GoSpan runs fn in a new goroutine inside a child span of the span found in ctx. The context
+// passed to fn is detached from the cancellation of ctx, so background work outlives the
+// request that started it, while keeping its values and the trace linkage through the child
+// span. The span is ended when fn returns; a panic in fn is recovered, recorded on the span
+// with an error status, and does not crash the process.
+//
+// Example usage:
+//
+//	tracing.GoSpan(r.Context(), "emails.send_confirmation", func(ctx context.Context) {
+//		mailer.SendConfirmation(ctx, order)
+//	})
+//
+// Parameters:
+//   - ctx: The parent context
+//   - name: The span name
+//   - fn: The function to run in the goroutine
+func GoSpan(ctx context.Context, name string, fn func(ctx context.Context)) {
+	ctx, span := Tracer("").Start(context.WithoutCancel(ctx), name)
+
+	go func() {
+		defer span.End()
+		defer func() {
+			if r := recover(); r != nil {
+				err := fmt.Errorf("panic: %v", r)
+				span.RecordError(err, trace.WithStackTrace(true))
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// installTestProvider stores configs holding a tracer provider that records the ended spans,
+// as Install does, so Tracer and the span helpers use it until the test ends.
+func installTestProvider(t *testing.T, opts ...sdktrace.TracerProviderOption) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(append(opts, sdktrace.WithSpanProcessor(recorder))...)
+
+	previous := installedConfigs.Swap(&configs.Configs{TracerProvider: tp})
+	t.Cleanup(func() {
+		installedConfigs.Store(previous)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	return recorder
+}
+
+// hasAttribute reports whether the attributes hold the given key and value.
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestStartWithTimeoutEndsExpiredSpan(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, span, cancel := StartWithTimeout(context.Background(), "payments.authorize", 10*time.Millisecond)
+	defer cancel()
+
+	<-ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d ended spans, want the expired span", len(spans))
+	}
+
+	if !hasAttribute(spans[0].Attributes(), attribute.Bool("timeout", true)) {
+		t.Error("timeout=true attribute missing")
+	}
+
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("status = %s, want Error", spans[0].Status().Code)
+	}
+
+	span.End()
+	if got := len(recorder.Ended()); got != 1 {
+		t.Errorf("ending the expired span again ended %d spans, want 1", got)
+	}
+}
+
+func TestStartWithTimeoutEndedInTime(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	_, span, cancel := StartWithTimeout(context.Background(), "payments.authorize", 20*time.Millisecond)
+	defer cancel()
+
+	span.End()
+	time.Sleep(40 * time.Millisecond)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d ended spans, want 1", len(spans))
+	}
+
+	if hasAttribute(spans[0].Attributes(), attribute.Bool("timeout", true)) || spans[0].Status().Code == codes.Error {
+		t.Error("a span ended in time was flagged as timed out")
+	}
+}
+
+func TestWithSpanRecordsError(t *testing.T) {
+	recorder := installTestProvider(t)
+	failure := errors.New("card declined")
+
+	var inner trace.SpanContext
+	err := WithSpan(context.Background(), "orders.process_payment", func(ctx context.Context) error {
+		inner = trace.SpanContextFromContext(ctx)
+		return failure
+	})
+	if err != failure {
+		t.Fatalf("WithSpan error = %v, want the error of fn", err)
+	}
+
+	span := recorder.Ended()[0]
+	if span.SpanContext().SpanID() != inner.SpanID() {
+		t.Error("fn did not run with the span context")
+	}
+
+	if span.Status().Code != codes.Error || span.Status().Description != "card declined" || len(span.Events()) != 1 {
+		t.Errorf("span status = %v with %d events, want the recorded error", span.Status(), len(span.Events()))
+	}
+}
+
+func TestWithSpanSetsOkStatus(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	if err := WithSpan(context.Background(), "orders.process_payment", func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("WithSpan: %v", err)
+	}
+
+	if code := recorder.Ended()[0].Status().Code; code != codes.Ok {
+		t.Errorf("status = %s, want Ok", code)
+	}
+}
+
+func TestWithSpanEndsSpanOnPanic(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	func() {
+		defer func() { _ = recover() }()
+
+		_ = WithSpan(context.Background(), "orders.process_payment", func(context.Context) error {
+			panic("boom")
+		})
+	}()
+
+	if got := len(recorder.Ended()); got != 1 {
+		t.Errorf("got %d ended spans, want the span of the panicking function", got)
+	}
+}
+
+func TestWithSpanTimeoutCompletedInTime(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	err := WithSpanTimeout(context.Background(), "inventory.reserve", time.Second, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("fn ran without a deadline")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithSpanTimeout: %v", err)
+	}
+
+	span := recorder.Ended()[0]
+	if span.Status().Code != codes.Ok || len(span.Events()) != 0 || hasAttribute(span.Attributes(), attribute.Bool("timeout", true)) {
+		t.Errorf("span status = %v with %d events, want Ok without timeout", span.Status(), len(span.Events()))
+	}
+}
+
+func TestWithSpanTimeoutRecordsDeadlineExceeded(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	err := WithSpanTimeout(context.Background(), "inventory.reserve", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WithSpanTimeout error = %v, want the error of fn", err)
+	}
+
+	span := recorder.Ended()[0]
+	if status := span.Status(); status.Code != codes.Error || status.Description != context.DeadlineExceeded.Error() {
+		t.Errorf("status = %v, want the deadline exceeded error", status)
+	}
+
+	if !hasAttribute(span.Attributes(), attribute.Bool("timeout", true)) {
+		t.Error("timeout=true attribute missing")
+	}
+
+	events := span.Events()
+	if len(events) != 2 || events[0].Name != "timeout" || !hasAttribute(events[0].Attributes, attribute.String("timeout", "10ms")) {
+		t.Errorf("events = %v, want the timeout event followed by the recorded error", events)
+	}
+}
+
+func TestWithSpanTimeoutIgnoresRecoveredTimeouts(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	err := WithSpanTimeout(context.Background(), "inventory.reserve", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithSpanTimeout: %v", err)
+	}
+
+	span := recorder.Ended()[0]
+	if span.Status().Code != codes.Ok || len(span.Events()) != 0 || hasAttribute(span.Attributes(), attribute.Bool("timeout", true)) {
+		t.Errorf("span status = %v with %d events, want Ok without timeout", span.Status(), len(span.Events()))
+	}
+}
+
+func TestWithSpanTimeoutIgnoresTheParentDeadline(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	parent, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := WithSpanTimeout(parent, "inventory.reserve", time.Minute, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WithSpanTimeout error = %v, want the error of fn", err)
+	}
+
+	span := recorder.Ended()[0]
+	if hasAttribute(span.Attributes(), attribute.Bool("timeout", true)) {
+		t.Error("the deadline of the parent context is reported as the timeout of the span")
+	}
+
+	if status := span.Status(); status.Code != codes.Error || len(span.Events()) != 1 || span.Events()[0].Name == "timeout" {
+		t.Errorf("status = %v with events %v, want the error recorded as in WithSpan", status, span.Events())
+	}
+}
+
+// waitEnded waits until the recorder holds the given number of ended spans and returns them.
+func waitEnded(t *testing.T, recorder *tracetest.SpanRecorder, n int) []sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if spans := recorder.Ended(); len(spans) >= n {
+			return spans
+		}
+	}
+
+	t.Fatalf("got %d ended spans, want %d", len(recorder.Ended()), n)
+
+	return nil
+}
+
+func TestGoSpanOutlivesTheParentContext(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, parent := Tracer("").Start(ctx, "orders.create")
+
+	release := make(chan struct{})
+	var fnErr error
+	GoSpan(ctx, "emails.send_confirmation", func(ctx context.Context) {
+		<-release
+		fnErr = ctx.Err()
+	})
+
+	parent.End()
+	cancel()
+	close(release)
+
+	spans := waitEnded(t, recorder, 2)
+	child := spans[1]
+
+	if fnErr != nil {
+		t.Errorf("fn context error = %v, want it detached from the cancellation of the parent", fnErr)
+	}
+
+	if child.Name() != "emails.send_confirmation" || child.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span %s is not a child of the parent span", child.Name())
+	}
+
+	if child.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Error("the child span is not in the trace of the parent")
+	}
+}
+
+func TestGoSpanRecordsPanics(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	GoSpan(context.Background(), "emails.send_confirmation", func(context.Context) {
+		panic("smtp unavailable")
+	})
+
+	span := waitEnded(t, recorder, 1)[0]
+	if status := span.Status(); status.Code != codes.Error || status.Description != "panic: smtp unavailable" {
+		t.Errorf("status = %v, want the recovered panic", status)
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("events = %v, want the recorded panic", events)
+	}
+}
+
+func TestEnsureSpanReusesTheRecordingSpan(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	ctx, outer := Tracer("").Start(context.Background(), "GET /orders")
+
+	ensuredCtx, span := EnsureSpan(ctx, "orders.list")
+	span.SetAttributes(attribute.String("order.count", "3"))
+	span.End()
+
+	if ensuredCtx != ctx || !span.SpanContext().Equal(outer.SpanContext()) {
+		t.Error("EnsureSpan did not return the span of the context")
+	}
+
+	if len(recorder.Ended()) != 0 || !outer.IsRecording() {
+		t.Fatal("ending the borrowed span ended the span of the context")
+	}
+
+	outer.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 || ended[0].Name() != "GET /orders" {
+		t.Fatalf("got %d ended spans, want the span of the context only", len(ended))
+	}
+
+	if !hasAttribute(ended[0].Attributes(), attribute.String("order.count", "3")) {
+		t.Error("the attributes set on the borrowed span are missing")
+	}
+}
+
+func TestEnsureSpanStartsASpanWithoutRecordingSpan(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	_, span := EnsureSpan(context.Background(), "orders.list")
+	span.End()
+
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), upstreamSpanContext)
+	_, child := EnsureSpan(ctx, "orders.get")
+	child.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 2 || ended[0].Name() != "orders.list" || ended[1].Name() != "orders.get" {
+		t.Fatalf("got %d ended spans, want a new span per call", len(ended))
+	}
+
+	if ended[1].Parent().SpanID() != upstreamSpanContext.SpanID() {
+		t.Error("the new span is not a child of the remote span of the context")
+	}
+}
+
+func TestRecordSpanUsesTheGivenTimestamps(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(1500 * time.Millisecond)
+
+	ctx := RecordSpan(context.Background(), "import.file", start, end, attribute.String("import.file", "orders.csv"))
+	RecordSpan(ctx, "import.row", start.Add(time.Second), end)
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d ended spans, want 2", len(ended))
+	}
+
+	file, row := ended[0], ended[1]
+	if !file.StartTime().Equal(start) || !file.EndTime().Equal(end) {
+		t.Errorf("span times = %s - %s, want %s - %s", file.StartTime(), file.EndTime(), start, end)
+	}
+
+	if !hasAttribute(file.Attributes(), attribute.String("import.file", "orders.csv")) {
+		t.Error("the attributes are missing")
+	}
+
+	if row.Parent().SpanID() != file.SpanContext().SpanID() || !row.StartTime().Equal(start.Add(time.Second)) {
+		t.Error("the sub-operation is not a child recorded at its own start time")
+	}
+}
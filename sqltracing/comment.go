@@ -0,0 +1,55 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sqltracing
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Comment appends the W3C trace context of the span found in the context to a SQL statement
+// as a comment in the sqlcommenter format, e.g. /*traceparent='00-...-01'*/, so the query logs
+// of the database can be correlated with the traces of the application. The tracestate is
+// added as well when present. The comment is placed before a trailing semicolon. The query is
+// returned unchanged when the context holds no valid span context or when it already contains
+// a comment, as the sqlcommenter specification requires.
+//
+// Example usage:
+//
+//	rows, err := db.QueryContext(ctx, sqltracing.Comment(ctx, "SELECT id FROM orders"))
+//
+// Parameters:
+//   - ctx: The context containing the trace information
+//   - query: The SQL statement
+//
+// Returns:
+//   - string: The statement with the trace context comment
+func Comment(ctx context.Context, query string) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() || strings.Contains(query, "/*") {
+		return query
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, url.QueryEscape(key)+"='"+url.QueryEscape(carrier.Get(key))+"'")
+	}
+
+	statement := strings.TrimRightFunc(query, func(r rune) bool { return r == ';' || unicode.IsSpace(r) })
+	suffix := query[len(statement):]
+
+	return statement + " /*" + strings.Join(pairs, ",") + "*/" + suffix
+}
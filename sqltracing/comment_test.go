@@ -0,0 +1,67 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package sqltracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commentedSpanContext is the span context whose trace context is commented in the tests.
+var commentedSpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	TraceFlags: trace.FlagsSampled,
+})
+
+func TestCommentAppendsTheTraceparent(t *testing.T) {
+	ctx := trace.ContextWithSpanContext(context.Background(), commentedSpanContext)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "SELECT id FROM orders",
+			want:  "SELECT id FROM orders /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/",
+		},
+		{
+			query: "SELECT id FROM orders;\n",
+			want:  "SELECT id FROM orders /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/;\n",
+		},
+		{
+			query: "SELECT id FROM orders /*action='list'*/",
+			want:  "SELECT id FROM orders /*action='list'*/",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Comment(ctx, tt.query); got != tt.want {
+			t.Errorf("Comment(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCommentEscapesTheTracestate(t *testing.T) {
+	state, err := trace.ParseTraceState("congo=t61rcWkgMzE")
+	if err != nil {
+		t.Fatalf("ParseTraceState: %v", err)
+	}
+
+	ctx := trace.ContextWithSpanContext(context.Background(), commentedSpanContext.WithTraceState(state))
+
+	want := "SELECT 1 /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01',tracestate='congo%3Dt61rcWkgMzE'*/"
+	if got := Comment(ctx, "SELECT 1"); got != want {
+		t.Errorf("Comment = %q, want %q", got, want)
+	}
+}
+
+func TestCommentWithoutSpan(t *testing.T) {
+	if got := Comment(context.Background(), "SELECT id FROM orders"); got != "SELECT id FROM orders" {
+		t.Errorf("Comment = %q, want the query unchanged", got)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package sqltracing provides database/sql integration for distributed tracing.
+// It wraps a driver.Connector so every query and statement execution
+// issued through the resulting *sql.DB is recorded as a child span of the caller's
+// context, using the tracer provider installed by the tracing package. Statements can also
+// carry the trace context to the database logs as sqlcommenter comments.
+package sqltracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/goxkit/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name used for the tracer
+	instrumentationName = "github.com/goxkit/tracing/sqltracing"
+)
+
+// connector wraps a driver.Connector so the connections it opens are traced.
+type connector struct {
+	driver.Connector
+
+	// system is the database system reported as db.system (e.g. postgresql)
+	system string
+}
+
+// NewConnector wraps a driver.Connector so that queries and executions performed through
+// its connections create spans named by the SQL operation (e.g. SELECT, INSERT) with the
+// db.system, db.operation and db.statement attributes. Spans are children of the context
+// passed to the *Context methods of *sql.DB, and follow the configured sampler.
+//
+// Example usage:
+//
+//	base, _ := pq.NewConnector(dsn)
+//	db := sql.OpenDB(sqltracing.NewConnector(base, "postgresql"))
+//	rows, err := db.QueryContext(ctx, "SELECT id FROM orders")
+//
+// Parameters:
+//   - base: The driver connector to wrap
+//   - system: The database system name reported in the db.system attribute
+//
+// Returns:
+//   - driver.Connector: A connector producing traced connections
+func NewConnector(base driver.Connector, system string) driver.Connector {
+	return &connector{
+		Connector: base,
+		system:    system,
+	}
+}
+
+// Open is a convenience that wraps the connector and opens a *sql.DB from it.
+//
+// Parameters:
+//   - base: The driver connector to wrap
+//   - system: The database system name reported in the db.system attribute
+//
+// Returns:
+//   - *sql.DB: A database handle whose operations are traced
+func Open(base driver.Connector, system string) *sql.DB {
+	return sql.OpenDB(NewConnector(base, system))
+}
+
+// Connect opens a connection with the wrapped connector and returns a traced connection.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedConn{Conn: conn, connector: c}, nil
+}
+
+// startSpan starts a client span describing the given SQL statement.
+func (c *connector) startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := operationName(query)
+
+	return tracing.Tracer(instrumentationName).Start(
+		ctx,
+		operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", c.system),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", query),
+		),
+	)
+}
+
+// endSpan records the error, if any, and ends the span. driver.ErrSkip is not an
+// error from the caller's perspective, since database/sql retries another way.
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != driver.ErrSkip {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// operationName derives a low-cardinality span name from the first keyword of a statement.
+func operationName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+// tracedConn wraps a driver.Conn and creates spans for statement execution.
+type tracedConn struct {
+	driver.Conn
+
+	// connector holds the tracer and database system of the connection
+	connector *connector
+}
+
+// PrepareContext prepares a statement whose executions are traced.
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedStmt{Stmt: stmt, connector: c.connector, query: query}, nil
+}
+
+// ExecContext executes a statement without preparing it, inside a span.
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.connector.startSpan(ctx, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	endSpan(span, err)
+
+	return result, err
+}
+
+// QueryContext runs a query without preparing it, inside a span.
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.connector.startSpan(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	endSpan(span, err)
+
+	return rows, err
+}
+
+// BeginTx starts a transaction, honoring the context and options when the driver supports them.
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+// Ping verifies the connection when the driver supports it.
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+// ResetSession resets the session state when the driver supports it.
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+// IsValid reports whether the connection may be reused when the driver supports it.
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+// CheckNamedValue delegates argument conversion to the driver when it supports it.
+func (c *tracedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+// tracedStmt wraps a prepared driver.Stmt and creates spans for its executions.
+type tracedStmt struct {
+	driver.Stmt
+
+	// connector holds the tracer and database system of the statement
+	connector *connector
+
+	// query is the SQL text used to prepare the statement
+	query string
+}
+
+// ExecContext executes the prepared statement inside a span.
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := s.connector.startSpan(ctx, s.query)
+
+	var (
+		result driver.Result
+		err    error
+	)
+
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.Stmt.Exec(namedValuesToValues(args))
+	}
+
+	endSpan(span, err)
+
+	return result, err
+}
+
+// QueryContext runs the prepared query inside a span.
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := s.connector.startSpan(ctx, s.query)
+
+	var (
+		rows driver.Rows
+		err  error
+	)
+
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(namedValuesToValues(args))
+	}
+
+	endSpan(span, err)
+
+	return rows, err
+}
+
+// CheckNamedValue delegates argument conversion to the statement when it supports it.
+func (s *tracedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+// namedValuesToValues converts named arguments to the positional form used by legacy drivers.
+func namedValuesToValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	return values
+}
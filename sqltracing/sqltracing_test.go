@@ -0,0 +1,133 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+//go:build cgo
+
+package sqltracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqliteConnector opens in-memory sqlite databases.
+type sqliteConnector struct{}
+
+// Connect opens a connection to a private in-memory database.
+func (sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	return (&sqlite3.SQLiteDriver{}).Open(":memory:")
+}
+
+// Driver returns the sqlite driver.
+func (sqliteConnector) Driver() driver.Driver {
+	return &sqlite3.SQLiteDriver{}
+}
+
+// installRecorder installs a tracer provider recording the ended spans as the global one.
+func installRecorder(t *testing.T, opts ...sdktrace.TracerProviderOption) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(append(opts, sdktrace.WithSpanProcessor(recorder))...)
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestQueryCreatesChildSpan(t *testing.T) {
+	recorder := installRecorder(t)
+
+	db := Open(sqliteConnector{}, "sqlite")
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+	var answer int
+	if err := db.QueryRowContext(ctx, "select 42").Scan(&answer); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	query := spans[0]
+	if query.Name() != "SELECT" || query.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span = %s (%s), want SELECT (client)", query.Name(), query.SpanKind())
+	}
+
+	if query.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("query span is not a child of the caller span")
+	}
+
+	want := map[attribute.Key]string{
+		"db.system":    "sqlite",
+		"db.operation": "SELECT",
+		"db.statement": "select 42",
+	}
+	for _, kv := range query.Attributes() {
+		if value, ok := want[kv.Key]; ok && kv.Value.AsString() != value {
+			t.Errorf("%s = %q, want %q", kv.Key, kv.Value.AsString(), value)
+		}
+		delete(want, kv.Key)
+	}
+
+	for key := range want {
+		t.Errorf("attribute %s missing", key)
+	}
+
+	if !query.EndTime().After(query.StartTime()) {
+		t.Error("query span has no duration")
+	}
+}
+
+func TestFailedExecRecordsError(t *testing.T) {
+	recorder := installRecorder(t)
+
+	db := Open(sqliteConnector{}, "sqlite")
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.ExecContext(context.Background(), "insert into missing values (1)"); err == nil {
+		t.Fatal("exec on a missing table succeeded")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	if spans[0].Name() != "INSERT" || spans[0].Status().Code != codes.Error {
+		t.Errorf("span = %s (%s), want INSERT (Error)", spans[0].Name(), spans[0].Status().Code)
+	}
+}
+
+func TestUnsampledQueryIsNotRecorded(t *testing.T) {
+	recorder := installRecorder(t, sdktrace.WithSampler(sdktrace.NeverSample()))
+
+	db := Open(sqliteConnector{}, "sqlite")
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.ExecContext(context.Background(), "create table orders (id integer)"); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	if spans := recorder.Ended(); len(spans) != 0 {
+		t.Errorf("got %d spans, want none", len(spans))
+	}
+}
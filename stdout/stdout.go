@@ -3,13 +3,78 @@
 // All rights reserved.
 
 // Package stdout provides stdout-based exporting capabilities for the tracing package.
-// This package is intended to enable trace data to be output to the console for
-// development and debugging purposes.
-//
-// Note: This is currently a placeholder package. For production tracing functionality,
-// use the otlp package which provides export to OpenTelemetry-compatible collectors.
+// It writes trace data to the console for development and debugging purposes, without
+// requiring an external collector. For production tracing functionality, use the otlp
+// package which provides export to OpenTelemetry-compatible collectors.
 package stdout
 
-// This package is a placeholder for future implementation of a console/stdout
-// trace exporter, which would be useful for local development and debugging
-// without requiring an external collector.
+import (
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/internal/provider"
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// Install configures and initializes an OpenTelemetry tracer provider that writes
+// every ended span to the standard output as pretty-printed JSON. The writer, the
+// compact single-line format and the omission of timestamps are set with
+// options.WithStdoutWriter, options.WithStdoutCompact and options.WithoutStdoutTimestamps,
+// and options.WithSyncExport writes spans as soon as they end. The provider is
+// configured with the same sampling, span limits, processors and resource attributes
+// as the OTLP installer, so local output matches what would be exported. The provider
+// is registered in the configs and as the global tracer provider.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the output format; exporter connection options have no effect
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The configured tracer provider with stdout export
+//   - error: Any error encountered during setup
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := NewProvider(cfgs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.Register(cfgs, options.New(opts...), tracerProvider)
+
+	return tracerProvider, nil
+}
+
+// NewProvider creates a tracer provider writing spans to the standard output exactly
+// like Install, without registering it in the configs nor as the global tracer provider.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the sampler or resource attributes
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The tracer provider with stdout export
+//   - error: Any error encountered during setup
+func NewProvider(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	o := options.New(opts...)
+
+	var exporterOpts []stdouttrace.Option
+	if o.StdoutWriter != nil {
+		exporterOpts = append(exporterOpts, stdouttrace.WithWriter(o.StdoutWriter))
+	}
+
+	if !o.StdoutCompact {
+		exporterOpts = append(exporterOpts, stdouttrace.WithPrettyPrint())
+	}
+
+	if o.StdoutWithoutTimestamps {
+		exporterOpts = append(exporterOpts, stdouttrace.WithoutTimestamps())
+	}
+
+	exp, err := stdouttrace.New(exporterOpts...)
+	if err != nil {
+		cfgs.Logger.Error("failed to create stdout trace exporter", zap.Error(err))
+		return nil, err
+	}
+
+	return provider.New(cfgs, o, exp)
+}
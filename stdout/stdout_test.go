@@ -0,0 +1,76 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package stdout
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	"go.uber.org/zap"
+)
+
+// writeSpan writes a single span with a provider created with the given options and returns
+// the output along with the trace ID of the span.
+func writeSpan(t *testing.T, opts ...options.Option) (string, string) {
+	t.Helper()
+
+	var out bytes.Buffer
+	cfgs := &configs.Configs{Logger: zap.NewNop(), AppConfigs: &configs.AppConfigs{Name: "orders"}}
+
+	tp, err := NewProvider(cfgs, append(opts, options.WithStdoutWriter(&out), options.WithSyncExport())...)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	return out.String(), span.SpanContext().TraceID().String()
+}
+
+func TestNewProviderWritesPrettyJSON(t *testing.T) {
+	out, traceID := writeSpan(t)
+
+	if !strings.Contains(out, `"Name": "orders.create"`) || !strings.Contains(out, traceID) {
+		t.Errorf("output does not hold the span name and trace ID:\n%s", out)
+	}
+
+	if strings.Count(out, "\n") < 2 {
+		t.Errorf("output is not pretty-printed:\n%s", out)
+	}
+}
+
+func TestNewProviderWritesCompactJSON(t *testing.T) {
+	out, traceID := writeSpan(t, options.WithStdoutCompact())
+
+	if !strings.Contains(out, `"Name":"orders.create"`) || !strings.Contains(out, traceID) {
+		t.Errorf("output does not hold the span name and trace ID:\n%s", out)
+	}
+
+	if strings.Count(out, "\n") != 1 {
+		t.Errorf("output is not a single line:\n%s", out)
+	}
+}
+
+func TestNewProviderWritesWithoutTimestamps(t *testing.T) {
+	withTimestamps, _ := writeSpan(t, options.WithStdoutCompact())
+	withoutTimestamps, _ := writeSpan(t, options.WithStdoutCompact(), options.WithoutStdoutTimestamps())
+
+	if !strings.Contains(withTimestamps, `"StartTime":"20`) {
+		t.Errorf("output does not hold the start time:\n%s", withTimestamps)
+	}
+
+	if strings.Contains(withoutTimestamps, `"StartTime":"20`) {
+		t.Errorf("output holds the start time:\n%s", withoutTimestamps)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/file"
+	"github.com/goxkit/tracing/noop"
+	"github.com/goxkit/tracing/options"
+	"github.com/goxkit/tracing/otlp"
+	"github.com/goxkit/tracing/stdout"
+	"github.com/goxkit/tracing/zipkin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// TenantAttributeKey is the resource attribute identifying the tenant of a tenant provider
+	TenantAttributeKey = "tenant.id"
+)
+
+var (
+	// tenantProviders holds the tracer providers registered per tenant
+	tenantProviders sync.Map
+)
+
+// RegisterTenant creates a tracer provider dedicated to a tenant and registers it, so
+// middleware can route the spans of each tenant with ProviderFor. The provider uses the
+// same exporter selection as Install; the options set its own sampler and resource
+// attributes. For OTLP, it shares the exporter connection of the configs, except when
+// compression is enabled or a traces-specific endpoint is set: the provider then dials its
+// own connection, closed when it shuts down. For the file exporter, tenants writing to the
+// same path share one writer. The tenant.id resource attribute is set on every span of the
+// provider. Registering a tenant again replaces its provider and shuts the previous one
+// down, flushing its pending spans.
+//
+// Example usage:
+//
+//	tracing.RegisterTenant(cfgs, "acme", options.WithSampler(sdktrace.TraceIDRatioBased(0.1)))
+//	tracer := tracing.ProviderFor("acme").Tracer("orders")
+//
+// Parameters:
+//   - cfgs: Application configurations including OTLP settings
+//   - tenant: The tenant identifier
+//   - opts: Installation options for the tenant provider, such as its sampler
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The tenant tracer provider
+//   - error: Any error encountered during setup
+func RegisterTenant(cfgs *configs.Configs, tenant string, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	opts = append([]options.Option{options.WithResourceAttributes(attribute.String(TenantAttributeKey, tenant))}, opts...)
+
+	var (
+		tracerProvider *sdktrace.TracerProvider
+		err            error
+	)
+
+	switch exporter(cfgs, opts...) {
+	case OTLPExporter:
+		tracerProvider, err = otlp.NewProvider(cfgs, opts...)
+	case ZipkinExporter:
+		tracerProvider, err = zipkin.NewProvider(cfgs, opts...)
+	case StdoutExporter:
+		tracerProvider, err = stdout.NewProvider(cfgs, opts...)
+	case FileExporter:
+		tracerProvider, err = file.NewProvider(cfgs, opts...)
+	default:
+		tracerProvider, err = noop.NewProvider(cfgs, opts...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if previous, replaced := tenantProviders.Swap(tenant, tracerProvider); replaced {
+		if err := previous.(*sdktrace.TracerProvider).Shutdown(context.Background()); err != nil {
+			cfgs.Logger.Warn("failed to shut down the replaced tenant tracer provider", zap.String("tenant", tenant), zap.Error(err))
+		}
+	}
+
+	return tracerProvider, nil
+}
+
+// ProviderFor returns the tracer provider registered for a tenant, falling back to the
+// global tracer provider when the tenant has none.
+//
+// Parameters:
+//   - tenant: The tenant identifier
+//
+// Returns:
+//   - trace.TracerProvider: The tracer provider of the tenant
+func ProviderFor(tenant string) trace.TracerProvider {
+	if tracerProvider, ok := tenantProviders.Load(tenant); ok {
+		return tracerProvider.(*sdktrace.TracerProvider)
+	}
+
+	return otel.GetTracerProvider()
+}
+
+// ShutdownTenants flushes and shuts down every registered tenant tracer provider and
+// removes them from the registry.
+//
+// Parameters:
+//   - ctx: Context bounding the shutdown
+//
+// Returns:
+//   - error: The errors returned by the providers, joined
+func ShutdownTenants(ctx context.Context) error {
+	var errs []error
+
+	tenantProviders.Range(func(tenant, tracerProvider any) bool {
+		tenantProviders.Delete(tenant)
+		errs = append(errs, tracerProvider.(*sdktrace.TracerProvider).Shutdown(ctx))
+		return true
+	})
+
+	return errors.Join(errs...)
+}
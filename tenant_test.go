@@ -0,0 +1,184 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// tenantCollector is an OTLP trace collector recording the tenant of each exported span.
+type tenantCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	// addr is the address the collector listens on
+	addr string
+
+	// mu guards spans
+	mu sync.Mutex
+
+	// spans maps the name of each exported span to the tenant.id of its resource
+	spans map[string]string
+}
+
+// newTenantCollector starts a collector on a local port until the test ends.
+func newTenantCollector(t *testing.T) *tenantCollector {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	c := &tenantCollector{addr: lis.Addr().String(), spans: map[string]string{}}
+
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, c)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return c
+}
+
+// Export records the tenant of every span of the request.
+func (c *tenantCollector) Export(_ context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, resourceSpans := range req.GetResourceSpans() {
+		tenant := ""
+		for _, kv := range resourceSpans.GetResource().GetAttributes() {
+			if kv.GetKey() == TenantAttributeKey {
+				tenant = kv.GetValue().GetStringValue()
+			}
+		}
+
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				c.spans[span.GetName()] = tenant
+			}
+		}
+	}
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// tenantOf returns the tenant of the exported span with the given name, and whether it was exported.
+func (c *tenantCollector) tenantOf(name string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tenant, ok := c.spans[name]
+
+	return tenant, ok
+}
+
+func TestTenantsHaveTheirOwnSamplerAndShareTheExporter(t *testing.T) {
+	collector := newTenantCollector(t)
+	t.Setenv(ExporterEnvKey, OTLPExporter)
+	t.Cleanup(func() { _ = ShutdownTenants(context.Background()) })
+
+	cfgs := newTestConfigs(true)
+	cfgs.OTLPConfigs.Endpoint = collector.addr
+
+	acme, err := RegisterTenant(cfgs, "acme", options.WithSampler(sdktrace.AlwaysSample()))
+	if err != nil {
+		t.Fatalf("RegisterTenant acme: %v", err)
+	}
+
+	conn := cfgs.OTLPExporterConn
+	t.Cleanup(func() { _ = conn.Close() })
+
+	globex, err := RegisterTenant(cfgs, "globex", options.WithSampler(sdktrace.NeverSample()))
+	if err != nil {
+		t.Fatalf("RegisterTenant globex: %v", err)
+	}
+
+	if conn == nil || cfgs.OTLPExporterConn != conn {
+		t.Error("the tenants do not share the exporter connection of the configs")
+	}
+
+	if ProviderFor("acme") != acme || ProviderFor("globex") != globex {
+		t.Fatal("ProviderFor does not return the registered tenant providers")
+	}
+
+	if ProviderFor("initech") != otel.GetTracerProvider() {
+		t.Error("an unknown tenant does not fall back to the global tracer provider")
+	}
+
+	_, span := ProviderFor("acme").Tracer("test").Start(context.Background(), "acme.orders.create")
+	span.End()
+
+	_, span = ProviderFor("globex").Tracer("test").Start(context.Background(), "globex.orders.create")
+	span.End()
+
+	if err := ShutdownTenants(context.Background()); err != nil {
+		t.Fatalf("ShutdownTenants: %v", err)
+	}
+
+	if tenant, ok := collector.tenantOf("acme.orders.create"); !ok || tenant != "acme" {
+		t.Errorf("the sampled span of acme was exported with tenant %q, exported %t", tenant, ok)
+	}
+
+	if _, ok := collector.tenantOf("globex.orders.create"); ok {
+		t.Error("the span of globex was exported although its sampler drops every span")
+	}
+
+	if _, ok := tenantProviders.Load("acme"); ok {
+		t.Error("ShutdownTenants kept the tenant providers registered")
+	}
+}
+
+func TestRegisterTenantShutsDownTheReplacedProvider(t *testing.T) {
+	t.Setenv(ExporterEnvKey, NoopExporter)
+	t.Cleanup(func() { _ = ShutdownTenants(context.Background()) })
+
+	cfgs := newTestConfigs(false)
+
+	replaced, err := RegisterTenant(cfgs, "acme")
+	if err != nil {
+		t.Fatalf("RegisterTenant: %v", err)
+	}
+
+	current, err := RegisterTenant(cfgs, "acme")
+	if err != nil {
+		t.Fatalf("RegisterTenant again: %v", err)
+	}
+
+	if ProviderFor("acme") != current {
+		t.Error("the tenant is still routed to the replaced provider")
+	}
+
+	if _, span := replaced.Tracer("test").Start(context.Background(), "orders.create"); span.IsRecording() {
+		t.Error("the replaced provider was not shut down")
+	}
+}
+
+func TestRegisterTenantWithoutExporterSetsTheTenant(t *testing.T) {
+	t.Setenv(ExporterEnvKey, NoopExporter)
+	t.Cleanup(func() { _ = ShutdownTenants(context.Background()) })
+
+	tp, err := RegisterTenant(newTestConfigs(false), "acme")
+	if err != nil {
+		t.Fatalf("RegisterTenant: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	tenant, ok := span.(sdktrace.ReadOnlySpan).Resource().Set().Value(TenantAttributeKey)
+	if !ok || tenant != attribute.StringValue("acme") {
+		t.Errorf("tenant.id = %v, want acme", tenant.Emit())
+	}
+}
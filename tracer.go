@@ -0,0 +1,93 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"sync/atomic"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationScope is the default instrumentation scope of the tracers returned by Tracer.
+type instrumentationScope struct {
+	// name is the scope name used when no name is given
+	name string
+
+	// version is the scope version attached to the tracers of this scope
+	version string
+}
+
+var (
+	// installedConfigs holds the configs passed to the last call of Install
+	installedConfigs atomic.Pointer[configs.Configs]
+
+	// installedExporter holds the exporter selected by the last call of Install
+	installedExporter atomic.Pointer[exporterInstall]
+
+	// defaultScope holds the default instrumentation scope set with SetInstrumentationScope
+	defaultScope atomic.Pointer[instrumentationScope]
+)
+
+// SetInstrumentationScope configures the default instrumentation scope of the tracers
+// returned by Tracer, which the span helpers of this package also use. The name is used
+// for tracers requested without a name, and the version is attached to the tracers of
+// that scope, so spans can be filtered by library version in the backend. Tracers of other
+// scopes don't get the version, as it describes the default scope only.
+//
+// Example usage:
+//
+//	tracing.SetInstrumentationScope("github.com/acme/orders", "v1.4.2")
+//
+// Parameters:
+//   - name: The default scope name
+//   - version: The scope version, empty for none
+func SetInstrumentationScope(name, version string) {
+	defaultScope.Store(&instrumentationScope{name: name, version: version})
+}
+
+// Tracer returns a named tracer from the tracer provider stored in the configs passed to
+// Install, falling back to the global tracer provider when Install has not been called or
+// the configs hold no tracer provider. Obtaining tracers through this function rather than
+// otel.GetTracerProvider() lets tests swap the provider held by the configs.
+//
+// An empty name selects the default scope name. Tracers of the default scope get the default
+// scope version unless the options set another one; see SetInstrumentationScope.
+//
+// Example usage:
+//
+//	tracer := tracing.Tracer("github.com/acme/orders")
+//	ctx, span := tracer.Start(ctx, "create-order")
+//	defer span.End()
+//
+// Parameters:
+//   - name: The instrumentation scope name of the tracer, empty for the default scope name
+//   - opts: Options of the tracer, such as its version
+//
+// Returns:
+//   - trace.Tracer: The tracer
+func Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	scope := defaultScope.Load()
+	if scope == nil {
+		scope = &instrumentationScope{name: instrumentationName}
+	}
+
+	if name == "" {
+		name = scope.name
+	}
+
+	if scope.version != "" && name == scope.name {
+		opts = append([]trace.TracerOption{trace.WithInstrumentationVersion(scope.version)}, opts...)
+	}
+
+	if cfgs := installedConfigs.Load(); cfgs != nil {
+		if tracerProvider, ok := cfgs.TracerProvider.(trace.TracerProvider); ok {
+			return tracerProvider.Tracer(name, opts...)
+		}
+	}
+
+	return otel.Tracer(name, opts...)
+}
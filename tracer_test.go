@@ -0,0 +1,117 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// installGlobalRecorder sets a global tracer provider recording the ended spans until the test ends.
+func installGlobalRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestTracerPrefersTheConfigsProvider(t *testing.T) {
+	global := installGlobalRecorder(t)
+
+	installed := tracetest.NewSpanRecorder()
+	previous := installedConfigs.Swap(&configs.Configs{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(installed))})
+	t.Cleanup(func() { installedConfigs.Store(previous) })
+
+	_, span := Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if len(installed.Ended()) != 1 || len(global.Ended()) != 0 {
+		t.Errorf("got %d spans from the configs provider and %d from the global one, want 1 and 0", len(installed.Ended()), len(global.Ended()))
+	}
+}
+
+func TestTracerFallsBackToTheGlobalProvider(t *testing.T) {
+	global := installGlobalRecorder(t)
+
+	previous := installedConfigs.Swap(nil)
+	t.Cleanup(func() { installedConfigs.Store(previous) })
+
+	_, span := Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if got := len(global.Ended()); got != 1 {
+		t.Errorf("got %d spans from the global provider, want 1", got)
+	}
+
+	installedConfigs.Store(newTestConfigs(false))
+
+	_, span = Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if got := len(global.Ended()); got != 2 {
+		t.Errorf("got %d spans from the global provider with configs holding no provider, want 2", got)
+	}
+}
+
+func TestSetInstrumentationScope(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	previous := defaultScope.Load()
+	t.Cleanup(func() { defaultScope.Store(previous) })
+
+	SetInstrumentationScope("github.com/acme/orders", "v1.4.2")
+
+	for _, tracer := range []trace.Tracer{Tracer(""), Tracer("github.com/acme/orders"), Tracer("github.com/acme/payments")} {
+		_, span := tracer.Start(context.Background(), "orders.create")
+		span.End()
+	}
+
+	_, span := Tracer("github.com/acme/legacy", trace.WithInstrumentationVersion("v0.9.0")).Start(context.Background(), "orders.create")
+	span.End()
+
+	want := []instrumentation.Scope{
+		{Name: "github.com/acme/orders", Version: "v1.4.2"},
+		{Name: "github.com/acme/orders", Version: "v1.4.2"},
+		{Name: "github.com/acme/payments", Version: ""},
+		{Name: "github.com/acme/legacy", Version: "v0.9.0"},
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != len(want) {
+		t.Fatalf("got %d spans, want %d", len(spans), len(want))
+	}
+
+	for i, span := range spans {
+		if got := span.InstrumentationScope(); got.Name != want[i].Name || got.Version != want[i].Version {
+			t.Errorf("scope = %s@%s, want %s@%s", got.Name, got.Version, want[i].Name, want[i].Version)
+		}
+	}
+}
+
+func TestTracerUsesThePackageScopeByDefault(t *testing.T) {
+	recorder := installTestProvider(t)
+
+	previous := defaultScope.Swap(nil)
+	t.Cleanup(func() { defaultScope.Store(previous) })
+
+	_, span := Tracer("").Start(context.Background(), "orders.create")
+	span.End()
+
+	if got := recorder.Ended()[0].InstrumentationScope(); got.Name != instrumentationName || got.Version != "" {
+		t.Errorf("scope = %s@%s, want the unversioned package scope", got.Name, got.Version)
+	}
+}
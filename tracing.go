@@ -9,6 +9,9 @@
 // The package integrates seamlessly with the configs package to provide
 // environment-specific configuration and supports multiple output options:
 // - OTLP export for observability platforms (Jaeger, Zipkin, etc.)
+// - Zipkin export for Zipkin servers without an OTLP collector
+// - Stdout export for local development and debugging
+// - File export for offline capture
 // - No-operation mode for testing and development
 //
 // It also provides utilities for trace context propagation in different protocols
@@ -16,30 +19,157 @@
 package tracing
 
 import (
+	"os"
+	"strings"
+
 	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/file"
 	"github.com/goxkit/tracing/noop"
+	"github.com/goxkit/tracing/options"
 	"github.com/goxkit/tracing/otlp"
+	"github.com/goxkit/tracing/stdout"
+	"github.com/goxkit/tracing/zipkin"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// ExporterEnvKey is the environment variable selecting the trace exporter
+	ExporterEnvKey = "TRACING_EXPORTER"
+
+	// OTelExporterEnvKey is the standard OpenTelemetry environment variable selecting the
+	// trace exporter, used when TRACING_EXPORTER is unset
+	OTelExporterEnvKey = "OTEL_TRACES_EXPORTER"
+
+	// OTLPExporter selects the OTLP exporter
+	OTLPExporter = "otlp"
+
+	// StdoutExporter selects the stdout exporter
+	StdoutExporter = "stdout"
+
+	// ZipkinExporter selects the Zipkin exporter
+	ZipkinExporter = "zipkin"
+
+	// FileExporter selects the file exporter
+	FileExporter = "file"
+
+	// NoopExporter selects the no-operation tracer
+	NoopExporter = "noop"
 )
 
 // Install initializes and configures a tracer provider based on the application configuration.
-// If OTLP is enabled in the configuration, it sets up an OpenTelemetry tracer with
-// export to the configured endpoint. Otherwise, it sets up a no-operation tracer
-// that satisfies the interface but doesn't collect or export spans.
+// The exporter is selected by options.WithExporter or the TRACING_EXPORTER environment
+// variable, which accept "otlp", "zipkin", "stdout", "file" or "noop". When neither is set,
+// the standard OTEL_TRACES_EXPORTER variable is honored, with its "console" and "none" values
+// mapped to stdout and noop. When none of them is set, OTLP export is used if it is enabled
+// in the configuration, and a no-operation tracer otherwise, which satisfies the interface
+// but doesn't collect or export spans.
 //
 // The configured tracer provider is stored in the configs object and also set as
-// the global tracer provider for the application.
+// the global tracer provider for the application. The configs are retained so Tracer
+// can use the tracer provider they hold.
 //
 // Parameters:
 //   - cfgs: Application configurations including OTLP settings
+//   - opts: Installation options forwarded to the selected installer
 //
 // Returns:
 //   - *sdktrace.TracerProvider: The configured tracer provider
 //   - error: Any error encountered during setup
-func Install(cfgs *configs.Configs) (*sdktrace.TracerProvider, error) {
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	installedConfigs.Store(cfgs)
+
+	var (
+		name           = exporter(cfgs, opts...)
+		tracerProvider *sdktrace.TracerProvider
+		err            error
+	)
+
+	switch name {
+	case OTLPExporter:
+		tracerProvider, err = otlp.Install(cfgs, opts...)
+	case ZipkinExporter:
+		tracerProvider, err = zipkin.Install(cfgs, opts...)
+	case StdoutExporter:
+		tracerProvider, err = stdout.Install(cfgs, opts...)
+	case FileExporter:
+		tracerProvider, err = file.Install(cfgs, opts...)
+	default:
+		tracerProvider, err = noop.Install(cfgs, opts...)
+	}
+
+	installedExporter.Store(&exporterInstall{name: name, tracerProvider: tracerProvider})
+
+	return tracerProvider, err
+}
+
+// exporter resolves the exporter to install from options.WithExporter, then from the
+// TRACING_EXPORTER environment variable, then from the OTEL_TRACES_EXPORTER one, falling
+// back to the OTLP configuration when none is set or the name is unknown.
+//
+// Parameters:
+//   - cfgs: Application configurations including OTLP settings
+//   - opts: Installation options, possibly selecting the exporter
+//
+// Returns:
+//   - string: The name of the exporter to install
+func exporter(cfgs *configs.Configs, opts ...options.Option) string {
+	value := options.New(opts...).Exporter
+	if value == "" {
+		value = os.Getenv(ExporterEnvKey)
+	}
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case OTLPExporter, ZipkinExporter, StdoutExporter, FileExporter, NoopExporter:
+		return value
+	case "":
+		if name, ok := otelExporter(cfgs); ok {
+			return name
+		}
+	default:
+		cfgs.Logger.Warn("unknown trace exporter, falling back to configuration", zap.String("exporter", value))
+	}
+
 	if cfgs.OTLPConfigs.Enabled {
-		return otlp.Install(cfgs)
+		return OTLPExporter
+	}
+
+	return NoopExporter
+}
+
+// otelExporter resolves the exporter named by the OTEL_TRACES_EXPORTER environment variable,
+// following the OpenTelemetry specification values. The variable may list several exporters,
+// but a single one is supported: the first one is used.
+//
+// Parameters:
+//   - cfgs: Application configurations including the logger
+//
+// Returns:
+//   - string: The name of the exporter to install
+//   - bool: Whether the variable names a supported exporter
+func otelExporter(cfgs *configs.Configs) (string, bool) {
+	raw := strings.ToLower(strings.TrimSpace(os.Getenv(OTelExporterEnvKey)))
+	if raw == "" {
+		return "", false
 	}
 
-	return noop.Install(cfgs)
+	value, rest, _ := strings.Cut(raw, ",")
+	value = strings.TrimSpace(value)
+
+	if rest != "" {
+		cfgs.Logger.Warn("multiple trace exporters are not supported, using the first one", zap.String("exporter", value))
+	}
+
+	switch value {
+	case OTLPExporter, ZipkinExporter:
+		return value, true
+	case "console":
+		return StdoutExporter, true
+	case "none":
+		return NoopExporter, true
+	default:
+		cfgs.Logger.Warn("unknown trace exporter, falling back to configuration", zap.String("exporter", value))
+		return "", false
+	}
 }
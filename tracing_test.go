@@ -0,0 +1,142 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	"go.uber.org/zap"
+)
+
+// newTestConfigs returns configs of a service whose OTLP export is enabled or not.
+func newTestConfigs(otlpEnabled bool) *configs.Configs {
+	return &configs.Configs{
+		Logger: zap.NewNop(),
+		AppConfigs: &configs.AppConfigs{
+			Name:        "orders",
+			Namespace:   "shop",
+			Environment: configs.StagingEnv,
+		},
+		OTLPConfigs: &configs.OTLPConfigs{Enabled: otlpEnabled},
+	}
+}
+
+// restoreInstall restores the configs and the exporter recorded by Install when the test ends.
+func restoreInstall(t *testing.T) {
+	t.Helper()
+
+	previousConfigs, previousExporter := installedConfigs.Load(), installedExporter.Load()
+	t.Cleanup(func() {
+		installedConfigs.Store(previousConfigs)
+		installedExporter.Store(previousExporter)
+	})
+}
+
+func TestExporterFromTracingExporter(t *testing.T) {
+	tests := []struct {
+		value       string
+		otlpEnabled bool
+		want        string
+	}{
+		{value: "otlp", want: OTLPExporter},
+		{value: "stdout", otlpEnabled: true, want: StdoutExporter},
+		{value: " NOOP ", otlpEnabled: true, want: NoopExporter},
+		{value: "jaeger", otlpEnabled: true, want: OTLPExporter},
+		{value: "jaeger", want: NoopExporter},
+		{value: "", otlpEnabled: true, want: OTLPExporter},
+		{value: "", want: NoopExporter},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(ExporterEnvKey, tt.value)
+
+		if got := exporter(newTestConfigs(tt.otlpEnabled)); got != tt.want {
+			t.Errorf("%s=%q with OTLP enabled %t: exporter = %s, want %s", ExporterEnvKey, tt.value, tt.otlpEnabled, got, tt.want)
+		}
+	}
+}
+
+func TestExporterFromOTelTracesExporter(t *testing.T) {
+	tests := []struct {
+		value       string
+		otlpEnabled bool
+		want        string
+	}{
+		{value: "otlp", want: OTLPExporter},
+		{value: "zipkin", want: ZipkinExporter},
+		{value: "console", otlpEnabled: true, want: StdoutExporter},
+		{value: "none", otlpEnabled: true, want: NoopExporter},
+		{value: "console,otlp", want: StdoutExporter},
+		{value: "prometheus", otlpEnabled: true, want: OTLPExporter},
+		{value: "stdout", want: NoopExporter},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(ExporterEnvKey, "")
+		t.Setenv(OTelExporterEnvKey, tt.value)
+
+		if got := exporter(newTestConfigs(tt.otlpEnabled)); got != tt.want {
+			t.Errorf("%s=%q with OTLP enabled %t: exporter = %s, want %s", OTelExporterEnvKey, tt.value, tt.otlpEnabled, got, tt.want)
+		}
+	}
+}
+
+func TestExporterPrefersTracingExporter(t *testing.T) {
+	t.Setenv(ExporterEnvKey, "noop")
+	t.Setenv(OTelExporterEnvKey, "console")
+
+	if got := exporter(newTestConfigs(true)); got != NoopExporter {
+		t.Errorf("exporter = %s, want %s", got, NoopExporter)
+	}
+}
+
+func TestExporterOptionOverridesTracingExporter(t *testing.T) {
+	t.Setenv(ExporterEnvKey, OTLPExporter)
+
+	if got := exporter(newTestConfigs(true), options.WithExporter(ZipkinExporter)); got != ZipkinExporter {
+		t.Errorf("exporter = %s, want %s", got, ZipkinExporter)
+	}
+
+	if got := exporter(newTestConfigs(true), options.WithExporter("")); got != OTLPExporter {
+		t.Errorf("exporter with an empty option = %s, want %s", got, OTLPExporter)
+	}
+}
+
+func TestInstallRunsSelectedExporter(t *testing.T) {
+	restoreInstall(t)
+	t.Setenv(OTelExporterEnvKey, "")
+
+	for _, name := range []string{StdoutExporter, NoopExporter} {
+		t.Setenv(ExporterEnvKey, name)
+
+		var out bytes.Buffer
+		cfgs := newTestConfigs(true)
+
+		tp, err := Install(cfgs, options.WithoutGlobalRegistration(), options.WithStdoutWriter(&out), options.WithSyncExport())
+		if err != nil {
+			t.Fatalf("Install with %s: %v", name, err)
+		}
+
+		if installed := installedExporter.Load(); installed.name != name || installed.tracerProvider != tp {
+			t.Errorf("installed exporter = %s, want %s", installed.name, name)
+		}
+
+		if cfgs.TracerProvider != tp || installedConfigs.Load() != cfgs {
+			t.Errorf("%s: the tracer provider is not stored in the configs", name)
+		}
+
+		_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+		span.End()
+		_ = tp.Shutdown(context.Background())
+
+		if exported := bytes.Contains(out.Bytes(), []byte("orders.create")); exported != (name == StdoutExporter) {
+			t.Errorf("%s: span written to stdout = %t", name, exported)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package websocket provides utilities for propagating trace context through WebSocket
+// connections. The trace context is extracted from the HTTP upgrade request, and carried by
+// each message in a small JSON envelope, since WebSocket frames have no headers.
+//
+// The package does not depend on a WebSocket library: messages are marshaled to and
+// unmarshaled from the bytes read and written with the library of choice.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var (
+	// WebSocketPropagator is a composite propagator that combines TraceContext and Baggage
+	// propagation for the upgrade request and the message envelopes.
+	WebSocketPropagator = propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+)
+
+// Envelope is the JSON frame of a WebSocket message carrying the trace context of its sender
+// along with the application payload.
+type Envelope struct {
+	// Trace holds the propagated trace context and baggage, keyed by propagation field
+	Trace propagation.MapCarrier `json:"trace,omitempty"`
+
+	// Payload is the application message
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ExtractUpgrade returns the context of the HTTP upgrade request carrying the trace context
+// and baggage extracted from its headers, so the spans of the connection, and of the
+// messages received over it, continue the trace of the client that opened it.
+//
+// Example usage:
+//
+//	func serveWS(w http.ResponseWriter, r *http.Request) {
+//		ctx := tracingws.ExtractUpgrade(r)
+//		conn, err := upgrader.Upgrade(w, r, nil)
+//		...
+//	}
+//
+// Parameters:
+//   - r: The upgrade request
+//
+// Returns:
+//   - context.Context: The request context with the extracted trace context
+func ExtractUpgrade(r *http.Request) context.Context {
+	return WebSocketPropagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// InjectUpgrade writes the trace context and baggage of ctx into the headers of the upgrade
+// request opening a connection, for use as the request header of the WebSocket dialer.
+//
+// Example usage:
+//
+//	header := http.Header{}
+//	tracingws.InjectUpgrade(ctx, header)
+//	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+//
+// Parameters:
+//   - ctx: The context holding the trace context to propagate
+//   - header: The headers of the upgrade request
+func InjectUpgrade(ctx context.Context, header http.Header) {
+	WebSocketPropagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Marshal encodes a message payload as a JSON envelope carrying the trace context and
+// baggage of ctx, to be written as a text or binary frame.
+//
+// Example usage:
+//
+//	frame, err := tracingws.Marshal(ctx, notification)
+//	if err != nil {
+//		return err
+//	}
+//	err = conn.WriteMessage(websocket.TextMessage, frame)
+//
+// Parameters:
+//   - ctx: The context holding the trace context to propagate
+//   - payload: The application message, encoded with encoding/json
+//
+// Returns:
+//   - []byte: The encoded envelope
+//   - error: Any error encountered while encoding the payload
+func Marshal(ctx context.Context, payload any) ([]byte, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := Envelope{Trace: propagation.MapCarrier{}, Payload: raw}
+	WebSocketPropagator.Inject(ctx, envelope.Trace)
+
+	return json.Marshal(envelope)
+}
+
+// Unmarshal decodes a JSON envelope read from a frame into payload, and returns a copy of ctx
+// carrying the trace context and baggage of the sender, so the span handling the message
+// belongs to the trace that produced it. ctx is typically the connection context returned by
+// ExtractUpgrade; it is returned as is when the envelope carries no trace context.
+//
+// Example usage:
+//
+//	var msg ChatMessage
+//	msgCtx, err := tracingws.Unmarshal(ctx, frame, &msg)
+//	if err != nil {
+//		return err
+//	}
+//	msgCtx, span := tracer.Start(msgCtx, "chat.receive", trace.WithSpanKind(trace.SpanKindConsumer))
+//	defer span.End()
+//
+// Parameters:
+//   - ctx: The parent context, e.g. the connection context
+//   - data: The frame holding the envelope
+//   - payload: A pointer receiving the application message
+//
+// Returns:
+//   - context.Context: The context with the extracted trace context
+//   - error: Any error encountered while decoding the envelope or the payload
+func Unmarshal(ctx context.Context, data []byte, payload any) (context.Context, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return ctx, err
+	}
+
+	if err := json.Unmarshal(envelope.Payload, payload); err != nil {
+		return ctx, err
+	}
+
+	if len(envelope.Trace) == 0 {
+		return ctx, nil
+	}
+
+	return WebSocketPropagator.Extract(ctx, envelope.Trace), nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// chatMessage is the application message exchanged in the tests.
+type chatMessage struct {
+	Text string `json:"text"`
+}
+
+func TestUpgradeAndMessagesShareTheTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	// The client opens the connection, then sends a message.
+	member, _ := baggage.NewMember("room.id", "r-1")
+	bag, _ := baggage.New(member)
+
+	clientCtx, dial := tracer.Start(baggage.ContextWithBaggage(context.Background(), bag), "chat.dial")
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	InjectUpgrade(clientCtx, req.Header)
+
+	sendCtx, send := tracer.Start(clientCtx, "chat.send")
+	frame, err := Marshal(sendCtx, chatMessage{Text: "hello"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	send.End()
+	dial.End()
+
+	// The server accepts the connection, then handles the message.
+	connCtx, accept := tracer.Start(ExtractUpgrade(req), "chat.accept")
+	accept.End()
+
+	var msg chatMessage
+	msgCtx, err := Unmarshal(connCtx, frame, &msg)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	_, receive := tracer.Start(msgCtx, "chat.receive")
+	receive.End()
+
+	if msg.Text != "hello" {
+		t.Errorf("payload = %+v, want the sent message", msg)
+	}
+
+	if got := baggage.FromContext(msgCtx).Member("room.id").Value(); got != "r-1" {
+		t.Errorf("room.id baggage = %q, want r-1", got)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	traceID := dial.SpanContext().TraceID()
+	for name, span := range spans {
+		if span.SpanContext().TraceID() != traceID {
+			t.Errorf("%s does not share the trace of the client", name)
+		}
+	}
+
+	if spans["chat.accept"].Parent().SpanID() != dial.SpanContext().SpanID() {
+		t.Error("the accept span is not a child of the dial span")
+	}
+
+	if spans["chat.receive"].Parent().SpanID() != send.SpanContext().SpanID() {
+		t.Error("the receive span is not a child of the send span")
+	}
+}
+
+func TestUnmarshalWithoutTraceContext(t *testing.T) {
+	frame, err := Marshal(context.Background(), chatMessage{Text: "hello"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var msg chatMessage
+	ctx, err := Unmarshal(context.Background(), frame, &msg)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if msg.Text != "hello" || trace.SpanContextFromContext(ctx).IsValid() {
+		t.Errorf("Unmarshal = (%v, %+v), want the payload without span context", trace.SpanContextFromContext(ctx), msg)
+	}
+}
+
+func TestUnmarshalRejectsMalformedFrames(t *testing.T) {
+	var msg chatMessage
+
+	for _, frame := range []string{`not json`, `{"payload":"text"}`} {
+		if _, err := Unmarshal(context.Background(), []byte(frame), &msg); err == nil {
+			t.Errorf("Unmarshal(%s) returned no error", frame)
+		}
+	}
+}
@@ -10,6 +10,7 @@ package zap
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -42,7 +43,9 @@ func (u *traceLog) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 }
 
 // Format extracts trace and span IDs from a context and returns them as a zap field
-// for structured logging. If no active span is found in the context, it returns a Skip field.
+// for structured logging. If no valid span context is found in the context, it returns a Skip field.
+// Spans that are not recorded locally (e.g. a sampled-out remote parent) still carry a valid
+// span context, so logs of non-sampled requests keep their trace ID.
 // This function allows easy inclusion of trace context in log entries.
 //
 // Example usage:
@@ -53,15 +56,109 @@ func (u *traceLog) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 //   - ctx: The context containing the trace information
 //
 // Returns:
-//   - zapcore.Field: A zap field containing the trace and span IDs, or a Skip field if no span is present
+//   - zapcore.Field: A zap field containing the trace and span IDs, or a Skip field if no span context is present
 func Format(ctx context.Context) zapcore.Field {
-	span := trace.SpanFromContext(ctx)
-	if !span.IsRecording() {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
 		return zap.Skip()
 	}
 
-	traceID := span.SpanContext().TraceID().String()
-	spanID := span.SpanContext().SpanID().String()
+	traceID := spanCtx.TraceID().String()
+	spanID := spanCtx.SpanID().String()
 
 	return zap.Inline(&traceLog{traceID, spanID})
 }
+
+// LoggerWithTrace returns a child of the base logger with the trace and span IDs of the
+// context attached as fields, so request-scoped loggers carry the trace context without
+// calling Format at each log site. The span is captured when the logger is built: spans
+// started afterwards from the context are not reflected. The base logger is returned as
+// is when the context holds no valid span context.
+//
+// Example usage:
+//
+//	logger := tracing.LoggerWithTrace(cfgs.Logger, ctx)
+//	logger.Info("Processing request", zap.String("user_id", userID))
+//
+// Parameters:
+//   - base: The logger to derive from
+//   - ctx: The context containing the trace information
+//
+// Returns:
+//   - *zap.Logger: The logger with the trace fields attached
+func LoggerWithTrace(base *zap.Logger, ctx context.Context) *zap.Logger {
+	field := Format(ctx)
+	if field.Type == zapcore.SkipType {
+		return base
+	}
+
+	return base.With(field)
+}
+
+// baggageLog holds the trace context and the selected baggage members of a context for
+// structured logging.
+type baggageLog struct {
+	// trace holds the trace and span IDs, nil when the context has no valid span context
+	trace *traceLog
+
+	// members holds the values of the selected baggage members, in the order of the keys
+	members []baggage.Member
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler interface for baggageLog, adding the
+// trace fields followed by one field per baggage member, named after the member key.
+//
+// Parameters:
+//   - enc: The zap object encoder to add the fields to
+//
+// Returns:
+//   - error: Always nil for this implementation
+func (b *baggageLog) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if b.trace != nil {
+		_ = b.trace.MarshalLogObject(enc)
+	}
+
+	for _, member := range b.members {
+		enc.AddString(member.Key(), member.Value())
+	}
+
+	return nil
+}
+
+// FormatWithBaggage behaves like Format and additionally adds the baggage members of the
+// context whose keys are allowed, such as the tenant or user carried with the request, one
+// field per member named after its key. Members that are not allowed are never logged, as
+// baggage may hold values received from other services. If the context holds neither a
+// valid span context nor any allowed member, it returns a Skip field.
+//
+// Example usage:
+//
+//	logger.Info("Processing request", tracing.FormatWithBaggage(ctx, "tenant.id", "user.id"))
+//
+// Parameters:
+//   - ctx: The context containing the trace information and baggage
+//   - keys: The baggage member keys allowed in the log entry
+//
+// Returns:
+//   - zapcore.Field: A zap field containing the trace and span IDs and the allowed baggage
+//     members, or a Skip field if none is present
+func FormatWithBaggage(ctx context.Context, keys ...string) zapcore.Field {
+	entry := &baggageLog{}
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		entry.trace = &traceLog{spanCtx.TraceID().String(), spanCtx.SpanID().String()}
+	}
+
+	bag := baggage.FromContext(ctx)
+	for _, key := range keys {
+		if member := bag.Member(key); member.Key() != "" {
+			entry.members = append(entry.members, member)
+		}
+	}
+
+	if entry.trace == nil && len(entry.members) == 0 {
+		return zap.Skip()
+	}
+
+	return zap.Inline(entry)
+}
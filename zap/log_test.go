@@ -0,0 +1,130 @@
+// Copyright (c) 2025 The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// remoteSpanContext is a valid span context received from an upstream service that sampled
+// the trace out, so no span records it locally.
+var remoteSpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	Remote:  true,
+})
+
+// logOnce writes a single entry with the given fields and returns its context fields.
+func logOnce(fields ...zapcore.Field) map[string]any {
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("processing request", fields...)
+
+	return logs.All()[0].ContextMap()
+}
+
+func TestFormatKeepsTraceOfNonRecordingSpans(t *testing.T) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remoteSpanContext)
+	if trace.SpanFromContext(ctx).IsRecording() {
+		t.Fatal("the remote span is recording")
+	}
+
+	fields := logOnce(Format(ctx))
+	if fields["trace_id"] != remoteSpanContext.TraceID().String() || fields["span_id"] != remoteSpanContext.SpanID().String() {
+		t.Errorf("fields = %v, want the remote trace and span IDs", fields)
+	}
+}
+
+func TestFormatSkipsContextsWithoutSpan(t *testing.T) {
+	if field := Format(context.Background()); field.Type != zapcore.SkipType {
+		t.Errorf("field type = %v, want Skip", field.Type)
+	}
+}
+
+func TestLoggerWithTraceAttachesTraceFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remoteSpanContext)
+
+	logger := LoggerWithTrace(zap.New(core), ctx)
+	logger.Info("order created")
+	logger.Info("order paid", zap.String("order_id", "o-1"))
+
+	for _, entry := range logs.All() {
+		fields := entry.ContextMap()
+		if fields["trace_id"] != remoteSpanContext.TraceID().String() || fields["span_id"] != remoteSpanContext.SpanID().String() {
+			t.Errorf("%s fields = %v, want the trace and span IDs of the context", entry.Message, fields)
+		}
+	}
+}
+
+func TestLoggerWithTraceReturnsBaseWithoutSpan(t *testing.T) {
+	base := zap.NewNop()
+
+	if LoggerWithTrace(base, context.Background()) != base {
+		t.Error("a context without span did not return the base logger")
+	}
+}
+
+// newBaggage returns the baggage holding the members given as key/value pairs.
+func newBaggage(t *testing.T, pairs ...string) baggage.Baggage {
+	t.Helper()
+
+	var members []baggage.Member
+	for i := 0; i < len(pairs); i += 2 {
+		member, err := baggage.NewMember(pairs[i], pairs[i+1])
+		if err != nil {
+			t.Fatalf("NewMember: %v", err)
+		}
+		members = append(members, member)
+	}
+
+	bag, err := baggage.New(members...)
+	if err != nil {
+		t.Fatalf("baggage.New: %v", err)
+	}
+
+	return bag
+}
+
+func TestFormatWithBaggageLogsAllowedMembersOnly(t *testing.T) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remoteSpanContext)
+	ctx = baggage.ContextWithBaggage(ctx, newBaggage(t, "tenant.id", "acme", "user.id", "42", "session.token", "secret"))
+
+	fields := logOnce(FormatWithBaggage(ctx, "tenant.id", "user.id", "region"))
+	if fields["trace_id"] != remoteSpanContext.TraceID().String() || fields["span_id"] != remoteSpanContext.SpanID().String() {
+		t.Errorf("fields = %v, want the remote trace and span IDs", fields)
+	}
+
+	if fields["tenant.id"] != "acme" || fields["user.id"] != "42" {
+		t.Errorf("fields = %v, want the allowed baggage members", fields)
+	}
+
+	if _, ok := fields["session.token"]; ok {
+		t.Error("a baggage member that is not allowed was logged")
+	}
+
+	if _, ok := fields["region"]; ok {
+		t.Error("an allowed key absent from the baggage was logged")
+	}
+}
+
+func TestFormatWithBaggageWithoutSpan(t *testing.T) {
+	ctx := baggage.ContextWithBaggage(context.Background(), newBaggage(t, "tenant.id", "acme"))
+
+	fields := logOnce(FormatWithBaggage(ctx, "tenant.id"))
+	if _, ok := fields["trace_id"]; ok || fields["tenant.id"] != "acme" {
+		t.Errorf("fields = %v, want the tenant only", fields)
+	}
+
+	if field := FormatWithBaggage(ctx, "user.id"); field.Type != zapcore.SkipType {
+		t.Errorf("field type = %v, want Skip", field.Type)
+	}
+}
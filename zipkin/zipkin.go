@@ -0,0 +1,63 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package zipkin provides Zipkin integration for tracing. It exports trace data directly
+// to a Zipkin server over HTTP, for deployments without an OTLP-capable collector.
+package zipkin
+
+import (
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/internal/provider"
+	"github.com/goxkit/tracing/options"
+	"go.opentelemetry.io/otel/exporters/zipkin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// Install configures and initializes an OpenTelemetry tracer provider that exports trace
+// data to a Zipkin server. The endpoint is set with options.WithZipkinEndpoint and defaults
+// to the OTEL_EXPORTER_ZIPKIN_ENDPOINT environment variable, then to
+// http://localhost:9411/api/v2/spans. The provider is configured with the same sampling,
+// span limits, processors and resource attributes as the OTLP installer, and is registered
+// in the configs and as the global tracer provider.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the Zipkin endpoint
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The configured tracer provider with Zipkin export capabilities
+//   - error: Any error encountered during setup
+func Install(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := NewProvider(cfgs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.Register(cfgs, options.New(opts...), tracerProvider)
+
+	return tracerProvider, nil
+}
+
+// NewProvider creates a tracer provider exporting to Zipkin exactly like Install, without
+// registering it in the configs nor as the global tracer provider.
+//
+// Parameters:
+//   - cfgs: Application configurations including service information
+//   - opts: Installation options such as the Zipkin endpoint
+//
+// Returns:
+//   - *sdktrace.TracerProvider: The tracer provider with Zipkin export capabilities
+//   - error: Any error encountered during setup
+func NewProvider(cfgs *configs.Configs, opts ...options.Option) (*sdktrace.TracerProvider, error) {
+	o := options.New(opts...)
+
+	exp, err := zipkin.New(o.ZipkinEndpoint)
+	if err != nil {
+		cfgs.Logger.Error("failed to create zipkin trace exporter", zap.Error(err))
+		return nil, err
+	}
+
+	return provider.New(cfgs, o, exp)
+}
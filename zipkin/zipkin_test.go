@@ -0,0 +1,111 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zipkin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"github.com/goxkit/tracing/options"
+	"go.uber.org/zap"
+)
+
+// zipkinSpan is the part of the Zipkin v2 JSON span checked by the tests.
+type zipkinSpan struct {
+	TraceID       string `json:"traceId"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	LocalEndpoint struct {
+		ServiceName string `json:"serviceName"`
+	} `json:"localEndpoint"`
+}
+
+// newZipkinServer starts a Zipkin stub server keeping the spans posted to it until the test ends.
+func newZipkinServer(t *testing.T) (*httptest.Server, func() []zipkinSpan) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		spans []zipkinSpan
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/spans" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s %s (%s), want a JSON POST to /api/v2/spans", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+
+		var posted []zipkinSpan
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Errorf("decoding the posted spans: %v", err)
+		}
+
+		mu.Lock()
+		spans = append(spans, posted...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []zipkinSpan {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return spans
+	}
+}
+
+func TestNewProviderPostsSpansToZipkin(t *testing.T) {
+	server, posted := newZipkinServer(t)
+
+	cfgs := &configs.Configs{
+		Logger:      zap.NewNop(),
+		AppConfigs:  &configs.AppConfigs{Name: "orders", Environment: configs.LocalEnv},
+		OTLPConfigs: &configs.OTLPConfigs{},
+	}
+
+	tp, err := NewProvider(cfgs, options.WithZipkinEndpoint(server.URL+"/api/v2/spans"))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "orders.create")
+	span.End()
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	spans := posted()
+	if len(spans) != 1 {
+		t.Fatalf("got %d posted spans, want 1", len(spans))
+	}
+
+	got := spans[0]
+	if got.Name != "orders.create" || got.LocalEndpoint.ServiceName != "orders" {
+		t.Errorf("span = %s of %s, want orders.create of orders", got.Name, got.LocalEndpoint.ServiceName)
+	}
+
+	if sc := span.SpanContext(); got.TraceID != sc.TraceID().String() || got.ID != sc.SpanID().String() {
+		t.Errorf("span IDs = %s/%s, want %s/%s", got.TraceID, got.ID, sc.TraceID(), sc.SpanID())
+	}
+
+	if cfgs.TracerProvider != nil {
+		t.Error("NewProvider registered the tracer provider in the configs")
+	}
+}
+
+func TestNewProviderRejectsInvalidEndpoints(t *testing.T) {
+	cfgs := &configs.Configs{Logger: zap.NewNop(), AppConfigs: &configs.AppConfigs{Name: "orders"}}
+
+	if _, err := NewProvider(cfgs, options.WithZipkinEndpoint("://zipkin")); err == nil {
+		t.Error("NewProvider accepted an invalid endpoint")
+	}
+}